@@ -1,59 +1,143 @@
 package app
 
 import (
-	"sync"
+	"sync/atomic"
 
 	"github.com/fatkulnurk/foundation/shared"
 )
 
-var (
-	app  *App
-	once sync.Once
-)
+// defaultApp dipakai oleh fungsi level package seperti Name() dan Env().
+// Dibuat dari environment saat pertama kali dipakai, bukan saat import.
+var defaultApp atomic.Pointer[App]
 
 type App struct {
-	cfg *Config
+	cfg Config
+}
+
+type Option func(*Config)
+
+// WithEnv mengganti environment, misalnya shared.EnvironmentTest
+func WithEnv(env string) Option {
+	return func(cfg *Config) {
+		cfg.Env = env
+	}
 }
 
-func init() {
-	New()
+func WithName(name string) Option {
+	return func(cfg *Config) {
+		cfg.Name = name
+	}
 }
 
+func WithVersion(version string) Option {
+	return func(cfg *Config) {
+		cfg.Version = version
+	}
+}
+
+// NewApp membuat App baru dari cfg; nil berarti LoadConfig(). Setiap App berdiri sendiri,
+// sehingga beberapa App bisa hidup dalam satu process.
+func NewApp(cfg *Config, opts ...Option) *App {
+	if cfg == nil {
+		cfg = LoadConfig()
+	}
+
+	a := &App{cfg: *cfg}
+	for _, opt := range opts {
+		opt(&a.cfg)
+	}
+
+	// Config yang dibuat manual biasanya tidak mengisi ShutdownTimeout;
+	// timeout 0 membuat shutdown langsung kena deadline
+	if a.cfg.ShutdownTimeout <= 0 {
+		a.cfg.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	return a
+}
+
+// New mengembalikan App default yang dipakai fungsi level package
 func New() *App {
-	once.Do(func() {
-		cfg := LoadConfig()
-		app = &App{
-			cfg: cfg,
-		}
-	})
+	if a := defaultApp.Load(); a != nil {
+		return a
+	}
+	defaultApp.CompareAndSwap(nil, NewApp(LoadConfig()))
+	return defaultApp.Load()
+}
+
+// SetDefault mengganti App default dan mengembalikan App sebelumnya
+func SetDefault(a *App) *App {
+	return defaultApp.Swap(a)
+}
+
+// Reset membuang App default, sehingga pemanggilan berikutnya membaca environment lagi
+func Reset() {
+	defaultApp.Store(nil)
+}
+
+// Override mengganti App default dengan salinan yang sudah diubah opts, untuk test.
+// Panggil fungsi yang dikembalikan untuk memulihkan App sebelumnya:
+//
+//	defer app.Override(app.WithEnv(shared.EnvironmentProduction))()
+func Override(opts ...Option) (restore func()) {
+	current := New()
+	previous := SetDefault(NewApp(&current.cfg, opts...))
+	return func() {
+		SetDefault(previous)
+	}
+}
+
+func (a *App) Name() string {
+	return a.cfg.Name
+}
+
+func (a *App) Version() string {
+	return a.cfg.Version
+}
+
+func (a *App) Env() string {
+	return a.cfg.Env
+}
+
+func (a *App) IsDevelopment() bool {
+	return a.cfg.Env == shared.EnvironmentDevelopment
+}
+
+func (a *App) IsTesting() bool {
+	return a.cfg.Env == shared.EnvironmentTest
+}
+
+func (a *App) IsStaging() bool {
+	return a.cfg.Env == shared.EnvironmentStaging
+}
 
-	return app
+func (a *App) IsProduction() bool {
+	return a.cfg.Env == shared.EnvironmentProduction
 }
 
 func Name() string {
-	return app.cfg.name
+	return New().Name()
 }
 
 func Version() string {
-	return app.cfg.version
+	return New().Version()
 }
 
 func Env() string {
-	return app.cfg.env
+	return New().Env()
 }
 
 func IsDevelopment() bool {
-	return app.cfg.env == shared.EnvironmentDevelopment
+	return New().IsDevelopment()
 }
 
 func IsTesting() bool {
-	return app.cfg.env == shared.EnvironmentTest
+	return New().IsTesting()
 }
 
 func IsStaging() bool {
-	return app.cfg.env == shared.EnvironmentStaging
+	return New().IsStaging()
 }
 
 func IsProduction() bool {
-	return app.cfg.env == shared.EnvironmentProduction
+	return New().IsProduction()
 }
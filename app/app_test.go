@@ -0,0 +1,44 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/fatkulnurk/foundation/shared"
+)
+
+func TestNewAppIsIndependent(t *testing.T) {
+	a := NewApp(&Config{Env: shared.EnvironmentProduction, Name: "billing"})
+	b := NewApp(&Config{Env: shared.EnvironmentTest}, WithName("users"), WithVersion("2.0.0"))
+
+	if !a.IsProduction() || a.Name() != "billing" {
+		t.Errorf("Unexpected first app: %+v", a.cfg)
+	}
+	if !b.IsTesting() || b.Name() != "users" || b.Version() != "2.0.0" {
+		t.Errorf("Unexpected second app: %+v", b.cfg)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	original := New()
+
+	restore := Override(WithEnv(shared.EnvironmentStaging), WithName("override"))
+	if !IsStaging() || Name() != "override" || Version() != original.Version() {
+		t.Errorf("Expected overridden default app, got %+v", New().cfg)
+	}
+
+	restore()
+	if New() != original {
+		t.Error("Expected restore to bring back the original app")
+	}
+}
+
+func TestResetReadsEnvironmentAgain(t *testing.T) {
+	previous := SetDefault(nil)
+	defer SetDefault(previous)
+
+	t.Setenv("APP_ENV", shared.EnvironmentProduction)
+	Reset()
+	if !IsProduction() {
+		t.Errorf("Expected APP_ENV to be read after Reset, got %s", Env())
+	}
+}
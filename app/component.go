@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// Component adalah proses yang berjalan selama aplikasi hidup, misalnya HTTP server atau queue worker.
+// Start harus blocking sampai component berhenti; ctx dibatalkan saat shutdown dimulai.
+// Stop dipanggil saat shutdown dan harus selesai sebelum ctx habis.
+type Component interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+type componentFunc struct {
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+func (c *componentFunc) Start(ctx context.Context) error { return c.start(ctx) }
+func (c *componentFunc) Stop(ctx context.Context) error  { return c.stop(ctx) }
+
+// NewComponent membuat Component dari sepasang fungsi start dan stop
+func NewComponent(start, stop func(ctx context.Context) error) Component {
+	return &componentFunc{start: start, stop: stop}
+}
+
+// HTTPServer menjalankan srv dengan ListenAndServe dan menghentikannya dengan graceful Shutdown
+func HTTPServer(srv *http.Server) Component {
+	return NewComponent(
+		func(ctx context.Context) error {
+			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		srv.Shutdown,
+	)
+}
+
+// Worker menjalankan worker yang Start-nya blocking dan Stop-nya tidak menerima context,
+// seperti queue.Worker. Start component kembali setelah Stop selesai, walaupun Start milik
+// worker belum kembali: asynq.Server.Run misalnya hanya berhenti saat menerima signal sendiri.
+func Worker(w interface {
+	Start() error
+	Stop()
+}) Component {
+	stopped := make(chan struct{})
+	var once sync.Once
+
+	return NewComponent(
+		func(ctx context.Context) error {
+			errc := make(chan error, 1)
+			go func() { errc <- w.Start() }()
+
+			select {
+			case err := <-errc:
+				return err
+			case <-stopped:
+				return nil
+			}
+		},
+		func(ctx context.Context) error {
+			return waitStop(ctx, func() {
+				w.Stop()
+				once.Do(func() { close(stopped) })
+			})
+		},
+	)
+}
+
+// WorkerPool mengelola pool yang sudah berjalan sejak dibuat, seperti workerpool.WorkerPool.
+// Start menunggu sampai shutdown, lalu Stop menunggu job yang sedang berjalan selesai.
+func WorkerPool(p interface{ Stop() }) Component {
+	return NewComponent(
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		},
+		func(ctx context.Context) error { return waitStop(ctx, p.Stop) },
+	)
+}
+
+// waitStop menjalankan stop dan berhenti menunggu ketika ctx habis
+func waitStop(ctx context.Context, stop func()) error {
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
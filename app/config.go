@@ -1,20 +1,30 @@
 package app
 
 import (
+	"time"
+
 	"github.com/fatkulnurk/foundation/shared"
 	"github.com/fatkulnurk/foundation/support"
 )
 
+// DefaultShutdownTimeout dipakai jika Config.ShutdownTimeout <= 0
+const DefaultShutdownTimeout = 30 * time.Second
+
 type Config struct {
-	env     string
-	name    string
-	version string
+	Env     string
+	Name    string
+	Version string
+
+	// ShutdownTimeout adalah batas waktu graceful shutdown di Kernel; <= 0 berarti DefaultShutdownTimeout
+	ShutdownTimeout time.Duration
 }
 
 func LoadConfig() *Config {
 	return &Config{
-		env:     support.GetEnv("APP_ENV", shared.EnvironmentDevelopment),
-		name:    support.GetEnv("APP_NAME", "Foundation"),
-		version: support.GetEnv("APP_VERSION", "1.0.0"),
+		Env:     support.GetEnv("APP_ENV", shared.EnvironmentDevelopment),
+		Name:    support.GetEnv("APP_NAME", "Foundation"),
+		Version: support.GetEnv("APP_VERSION", "1.0.0"),
+
+		ShutdownTimeout: support.GetDurationEnv("APP_SHUTDOWN_TIMEOUT", DefaultShutdownTimeout),
 	}
 }
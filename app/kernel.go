@@ -0,0 +1,211 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatkulnurk/foundation/module"
+)
+
+// PhaseError menunjukkan module atau component mana yang gagal dan pada fase apa
+type PhaseError struct {
+	Kind  string // module atau component
+	Name  string
+	Phase string // boot, start, run atau stop
+	Err   error
+}
+
+func (e *PhaseError) Error() string {
+	return fmt.Sprintf("%s %q failed to %s: %v", e.Kind, e.Name, e.Phase, e.Err)
+}
+
+func (e *PhaseError) Unwrap() error {
+	return e.Err
+}
+
+// Kernel menjalankan lifecycle aplikasi: Register, Boot dan Start semua module,
+// menjalankan component, lalu graceful shutdown saat menerima signal atau saat ada component yang berhenti
+type Kernel struct {
+	app             *App
+	modules         []module.Module
+	moduleConfig    *module.Config
+	components      []namedComponent
+	shutdownTimeout time.Duration
+	signals         []os.Signal
+}
+
+type namedComponent struct {
+	name      string
+	component Component
+}
+
+type KernelOption func(*Kernel)
+
+// WithShutdownTimeout mengganti batas waktu graceful shutdown (default APP_SHUTDOWN_TIMEOUT).
+// d <= 0 berarti DefaultShutdownTimeout.
+func WithShutdownTimeout(d time.Duration) KernelOption {
+	return func(k *Kernel) {
+		k.shutdownTimeout = d
+	}
+}
+
+// WithModuleConfig mengganti konfigurasi module yang aktif (default module.LoadConfig)
+func WithModuleConfig(cfg *module.Config) KernelOption {
+	return func(k *Kernel) {
+		k.moduleConfig = cfg
+	}
+}
+
+// WithSignals mengganti signal yang memicu shutdown (default SIGINT dan SIGTERM)
+func WithSignals(signals ...os.Signal) KernelOption {
+	return func(k *Kernel) {
+		k.signals = signals
+	}
+}
+
+func NewKernel(app *App, opts ...KernelOption) *Kernel {
+	k := &Kernel{
+		app:             app,
+		moduleConfig:    module.LoadConfig(),
+		shutdownTimeout: app.cfg.ShutdownTimeout,
+		signals:         []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	if k.shutdownTimeout <= 0 {
+		k.shutdownTimeout = DefaultShutdownTimeout
+	}
+	return k
+}
+
+// App mengembalikan App milik kernel
+func (k *Kernel) App() *App {
+	return k.app
+}
+
+// Register menambahkan module. Saat Run, module yang di-disable dibuang dan sisanya diurutkan
+// berdasarkan DependsOn; module yang tidak saling bergantung tetap dalam urutan Register.
+func (k *Kernel) Register(modules ...module.Module) {
+	k.modules = append(k.modules, modules...)
+}
+
+// Component menambahkan proses yang dijalankan setelah semua module start.
+// name dipakai di pesan error untuk menunjukkan component yang gagal.
+func (k *Kernel) Component(name string, c Component) {
+	k.components = append(k.components, namedComponent{name: name, component: c})
+}
+
+// Run menjalankan semua fase dan blocking sampai ctx dibatalkan, signal diterima,
+// atau ada component yang berhenti. Error dari setiap module dan component
+// dibungkus PhaseError lalu digabung.
+func (k *Kernel) Run(ctx context.Context) error {
+	modules, err := module.Resolve(k.modules, k.moduleConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range modules {
+		m.Register()
+	}
+
+	for _, m := range modules {
+		if b, ok := m.(module.Booter); ok {
+			if err := b.Boot(ctx); err != nil {
+				return moduleError(m, "boot", err)
+			}
+		}
+	}
+
+	var started []module.Module
+	for _, m := range modules {
+		if s, ok := m.(module.Starter); ok {
+			if err := s.Start(ctx); err != nil {
+				stopCtx, cancelStop := k.shutdownContext(ctx)
+				defer cancelStop()
+				return errors.Join(moduleError(m, "start", err), k.shutdown(stopCtx, nil, started))
+			}
+		}
+		started = append(started, m)
+	}
+
+	runCtx, stopSignals := signal.NotifyContext(ctx, k.signals...)
+	defer stopSignals()
+
+	componentCtx, cancel := context.WithCancel(runCtx)
+	defer cancel()
+
+	exited := make(chan error, len(k.components))
+	for _, nc := range k.components {
+		go func() {
+			err := nc.component.Start(componentCtx)
+			if err != nil {
+				err = &PhaseError{Kind: "component", Name: nc.name, Phase: "run", Err: err}
+			}
+			exited <- err
+		}()
+	}
+
+	// Tunggu signal, ctx dibatalkan, atau component pertama yang berhenti
+	var errs []error
+	running := len(k.components)
+	select {
+	case <-runCtx.Done():
+	case err := <-exited:
+		running--
+		errs = append(errs, err)
+	}
+	cancel()
+
+	stopCtx, cancelStop := k.shutdownContext(ctx)
+	defer cancelStop()
+
+	errs = append(errs, k.shutdown(stopCtx, k.components, started))
+
+	// Component yang sudah di-stop seharusnya segera keluar dari Start
+	for ; running > 0; running-- {
+		select {
+		case err := <-exited:
+			errs = append(errs, err)
+		case <-stopCtx.Done():
+			errs = append(errs, fmt.Errorf("%d components did not exit: %w", running, stopCtx.Err()))
+			running = 0
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// shutdownContext tetap berjalan walaupun ctx milik Run sudah dibatalkan, dibatasi shutdownTimeout
+func (k *Kernel) shutdownContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), k.shutdownTimeout)
+}
+
+// shutdown menghentikan component lalu module dengan urutan terbalik dari urutan start
+func (k *Kernel) shutdown(ctx context.Context, components []namedComponent, modules []module.Module) error {
+	var errs []error
+	for i := len(components) - 1; i >= 0; i-- {
+		if err := components[i].component.Stop(ctx); err != nil {
+			errs = append(errs, &PhaseError{Kind: "component", Name: components[i].name, Phase: "stop", Err: err})
+		}
+	}
+
+	for i := len(modules) - 1; i >= 0; i-- {
+		if s, ok := modules[i].(module.Stopper); ok {
+			if err := s.Stop(ctx); err != nil {
+				errs = append(errs, moduleError(modules[i], "stop", err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func moduleError(m module.Module, phase string, err error) error {
+	return &PhaseError{Kind: "module", Name: module.Name(m), Phase: phase, Err: err}
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/module"
+)
+
+type testModule struct {
+	name    string
+	deps    []string
+	events  *[]string
+	bootErr error
+}
+
+func (m *testModule) Name() string        { return m.name }
+func (m *testModule) DependsOn() []string { return m.deps }
+
+func (m *testModule) Register() { *m.events = append(*m.events, "register "+m.name) }
+
+func (m *testModule) Boot(ctx context.Context) error {
+	*m.events = append(*m.events, "boot "+m.name)
+	return m.bootErr
+}
+
+func (m *testModule) Start(ctx context.Context) error {
+	*m.events = append(*m.events, "start "+m.name)
+	return nil
+}
+
+func (m *testModule) Stop(ctx context.Context) error {
+	*m.events = append(*m.events, "stop "+m.name)
+	return nil
+}
+
+func TestKernelLifecycle(t *testing.T) {
+	var events []string
+	k := NewKernel(New(), WithShutdownTimeout(time.Second), WithModuleConfig(&module.Config{Disabled: []string{"reports"}}))
+	k.Register(
+		&testModule{name: "billing", deps: []string{"users"}, events: &events},
+		&testModule{name: "reports", events: &events},
+		&testModule{name: "users", events: &events},
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{}
+	k.Component("http", NewComponent(
+		func(ctx context.Context) error {
+			if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		srv.Shutdown,
+	))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- k.Run(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+
+	want := "register users,register billing,boot users,boot billing,start users,start billing,stop billing,stop users"
+	if got := strings.Join(events, ","); got != want {
+		t.Errorf("Unexpected phase order:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestKernelReportsFailingComponent(t *testing.T) {
+	var events []string
+	k := NewKernel(New(), WithShutdownTimeout(time.Second))
+	k.Register(&testModule{name: "users", events: &events})
+	k.Component("worker", NewComponent(
+		func(ctx context.Context) error { return errors.New("redis unavailable") },
+		func(ctx context.Context) error { return nil },
+	))
+	k.Component("pool", WorkerPool(&stopFunc{}))
+
+	err := k.Run(context.Background())
+
+	var phaseErr *PhaseError
+	if !errors.As(err, &phaseErr) || phaseErr.Name != "worker" || phaseErr.Phase != "run" {
+		t.Fatalf("Expected run error for worker, got %v", err)
+	}
+	if events[len(events)-1] != "stop users" {
+		t.Errorf("Expected modules to be stopped after failure, got %v", events)
+	}
+}
+
+func TestKernelBootError(t *testing.T) {
+	var events []string
+	k := NewKernel(New())
+	k.Register(&testModule{name: "users", events: &events, bootErr: errors.New("migration failed")})
+
+	err := k.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `failed to boot: migration failed`) {
+		t.Errorf("Expected boot error, got %v", err)
+	}
+}
+
+type stopFunc struct{}
+
+func (stopFunc) Stop() {}
+
+func TestKernelShutdownWithZeroConfig(t *testing.T) {
+	a := NewApp(&Config{})
+	if a.cfg.ShutdownTimeout != DefaultShutdownTimeout {
+		t.Errorf("Expected default shutdown timeout, got %v", a.cfg.ShutdownTimeout)
+	}
+
+	var events []string
+	k := NewKernel(a, WithModuleConfig(&module.Config{}))
+	k.Register(&testModule{name: "users", events: &events})
+
+	stopped := make(chan struct{})
+	k.Component("worker", NewComponent(
+		func(ctx context.Context) error {
+			<-stopped
+			return nil
+		},
+		func(ctx context.Context) error {
+			// Stopper yang butuh waktu tetap sempat berjalan sampai selesai
+			select {
+			case <-time.After(20 * time.Millisecond):
+				close(stopped)
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- k.Run(ctx) }()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected clean shutdown with zero-value Config, got %v", err)
+	}
+	if events[len(events)-1] != "stop users" {
+		t.Errorf("Expected modules to be stopped, got %v", events)
+	}
+}
+
+// signalWorker meniru asynq.Server.Run: Start hanya kembali saat menerima signal, bukan karena Stop
+type signalWorker struct {
+	signal  chan struct{}
+	stopped chan struct{}
+}
+
+func (w *signalWorker) Start() error {
+	<-w.signal
+	return nil
+}
+
+func (w *signalWorker) Stop() { close(w.stopped) }
+
+func TestKernelStopsWorkerThatWaitsForSignal(t *testing.T) {
+	w := &signalWorker{signal: make(chan struct{}), stopped: make(chan struct{})}
+	t.Cleanup(func() { close(w.signal) })
+
+	k := NewKernel(New(), WithShutdownTimeout(time.Second))
+	k.Component("queue", Worker(w))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- k.Run(ctx) }()
+
+	time.Sleep(20 * time.Millisecond)
+	start := time.Now()
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected Run to return once Stop finished, took %v", elapsed)
+	}
+	select {
+	case <-w.stopped:
+	default:
+		t.Error("Expected worker to be stopped")
+	}
+}
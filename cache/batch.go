@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"errors"
+)
+
+// GetMany membaca banyak key sekaligus. Map hasil hanya berisi key yang ditemukan.
+// Kalau c bukan BatchCache, Get dipanggil satu per satu.
+func GetMany(ctx context.Context, c Cache, keys []string) (map[string]string, error) {
+	if b, ok := c.(BatchCache); ok {
+		return b.GetMany(ctx, keys)
+	}
+
+	result := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, err := c.Get(ctx, key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// SetMany menyimpan banyak key dengan TTL yang sama.
+// Kalau c bukan BatchCache, Set dipanggil satu per satu.
+func SetMany(ctx context.Context, c Cache, values map[string]any, ttlSeconds int) error {
+	if b, ok := c.(BatchCache); ok {
+		return b.SetMany(ctx, values, ttlSeconds)
+	}
+
+	for key, value := range values {
+		if err := c.Set(ctx, key, value, ttlSeconds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteMany menghapus banyak key sekaligus.
+// Kalau c bukan BatchCache, Delete dipanggil satu per satu.
+func DeleteMany(ctx context.Context, c Cache, keys []string) error {
+	if b, ok := c.(BatchCache); ok {
+		return b.DeleteMany(ctx, keys)
+	}
+
+	for _, key := range keys {
+		if err := c.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,11 +2,103 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
+var (
+	// ErrNotFound dikembalikan Get kalau key tidak ada atau sudah expired, di semua backend.
+	ErrNotFound = errors.New("key not found")
+
+	// ErrFlushWithoutPrefix dikembalikan RedisCache.Flush kalau Config.Prefix kosong,
+	// supaya tidak menghapus key milik aplikasi lain di database Redis yang sama.
+	ErrFlushWithoutPrefix = errors.New("cache: flush requires a non-empty prefix")
+
+	// ErrNotInteger dikembalikan Increment/Decrement kalau value yang tersimpan bukan integer.
+	ErrNotInteger = errors.New("cache: value is not an integer")
+
+	// ErrFarNotRedis dikembalikan NewTieredCache kalau far cache bukan RedisCache.
+	ErrFarNotRedis = errors.New("cache: tiered cache requires a RedisCache as far cache")
+
+	// ErrNearNotFlusher dikembalikan NewTieredCache kalau near cache tidak mengimplementasikan Flusher.
+	ErrNearNotFlusher = errors.New("cache: tiered cache requires a near cache that implements Flusher")
+
+	// ErrLoaderPanic dikembalikan Remember ke pemanggil yang menunggu loader yang panic.
+	ErrLoaderPanic = errors.New("cache: loader panicked")
+)
+
+// Cache adalah kontrak yang wajib dipenuhi semua backend (lihat package cachetest):
+//   - Get untuk key yang tidak ada / expired mengembalikan ErrNotFound
+//   - ttlSeconds <= 0 berarti tidak ada expiry
+//   - value disimpan sebagai string: string dan []byte apa adanya, fmt.Stringer lewat String(),
+//     selain itu lewat fmt.Sprint
+//   - Delete untuk key yang tidak ada bukan error
 type Cache interface {
 	Set(ctx context.Context, key string, value any, ttlSeconds int) error
 	Get(ctx context.Context, key string) (string, error)
 	Delete(ctx context.Context, key string) error
 	Has(ctx context.Context, key string) (bool, error)
 }
+
+// Rememberer diimplementasikan cache yang bisa men-dedup loader untuk key yang sama.
+// Pakai fungsi Remember supaya tetap jalan untuk Cache yang tidak mengimplementasikannya.
+type Rememberer interface {
+	Remember(ctx context.Context, key string, ttlSeconds int, loader Loader, opts ...RememberOption) (string, error)
+}
+
+// BatchCache diimplementasikan cache yang bisa memproses banyak key dalam satu round-trip.
+// Pakai fungsi GetMany, SetMany dan DeleteMany supaya tetap jalan untuk Cache lain.
+type BatchCache interface {
+	// GetMany hanya mengembalikan key yang ditemukan (hit). Key yang tidak ada di map berarti miss.
+	GetMany(ctx context.Context, keys []string) (map[string]string, error)
+	SetMany(ctx context.Context, values map[string]any, ttlSeconds int) error
+	DeleteMany(ctx context.Context, keys []string) error
+}
+
+// TagCache diimplementasikan cache yang bisa mengelompokkan key dengan tag,
+// lalu menghapus semua key dengan tag tertentu sekaligus.
+type TagCache interface {
+	SetWithTags(ctx context.Context, key string, value any, ttlSeconds int, tags ...string) error
+	InvalidateTags(ctx context.Context, tags ...string) error
+}
+
+// Flusher diimplementasikan cache yang bisa menghapus semua key di bawah Config.Prefix.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// AtomicCache diimplementasikan cache yang mendukung operasi read-modify-write atomik,
+// misalnya untuk rate counter dan kuota.
+type AtomicCache interface {
+	// Increment menambah nilai integer key sebesar delta dan mengembalikan nilai barunya.
+	// Kalau key belum ada, key dibuat dengan nilai delta dan ttlSeconds.
+	// TTL key yang sudah ada tidak berubah.
+	Increment(ctx context.Context, key string, delta int64, ttlSeconds int) (int64, error)
+
+	// Decrement sama dengan Increment dengan -delta.
+	Decrement(ctx context.Context, key string, delta int64, ttlSeconds int) (int64, error)
+
+	// SetNX menyimpan value hanya kalau key belum ada. true kalau tersimpan.
+	SetNX(ctx context.Context, key string, value any, ttlSeconds int) (bool, error)
+
+	// CompareAndSwap mengganti value hanya kalau value sekarang sama dengan old.
+	// TTL key tidak berubah. false kalau key tidak ada atau value berbeda.
+	CompareAndSwap(ctx context.Context, key string, old, new any) (bool, error)
+}
+
+// StatsProvider diimplementasikan cache yang mencatat statistik (LocalCache, RedisCache, InstrumentedCache).
+type StatsProvider interface {
+	Stats() Stats
+}
+
+// Locker membuat lock untuk mutual exclusion, misalnya antar replica (NewRedisLocker)
+// atau dalam satu proses untuk testing (NewLocalLocker).
+type Locker interface {
+	// TryLock mencoba mengambil lock sekali. Mengembalikan ErrLockNotAcquired kalau
+	// lock sedang dipegang pihak lain. Lock otomatis lepas setelah ttl; ttl <= 0
+	// mengembalikan ErrInvalidLockTTL.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error)
+
+	// Lock menunggu sampai lock didapat atau ctx selesai.
+	Lock(ctx context.Context, key string, ttl time.Duration) (*Lock, error)
+}
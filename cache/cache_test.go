@@ -0,0 +1,74 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/fatkulnurk/foundation/cache"
+	"github.com/fatkulnurk/foundation/cache/cachetest"
+	"github.com/redis/go-redis/v9"
+)
+
+// newMiniredis menjalankan Redis in-process dan mengembalikan client-nya.
+func newMiniredis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return mr, client
+}
+
+func TestLocalCache_Conformance(t *testing.T) {
+	cachetest.Run(t, cachetest.Harness{
+		New: func(t *testing.T, cfg *cache.Config) cache.Cache {
+			return cache.NewLocalCache(cfg)
+		},
+	})
+}
+
+func TestRedisCache_Conformance(t *testing.T) {
+	mr, client := newMiniredis(t)
+
+	cachetest.Run(t, cachetest.Harness{
+		New: func(t *testing.T, cfg *cache.Config) cache.Cache {
+			return cache.NewRedisCache(cfg, client)
+		},
+		Advance: func(d time.Duration) {
+			mr.FastForward(d)
+		},
+	})
+}
+
+func TestRedisCache_FlushWithoutPrefix(t *testing.T) {
+	_, client := newMiniredis(t)
+	c := cache.NewRedisCache(&cache.Config{}, client)
+
+	if err := c.(cache.Flusher).Flush(context.Background()); !errors.Is(err, cache.ErrFlushWithoutPrefix) {
+		t.Fatalf("Flush error = %v, want ErrFlushWithoutPrefix", err)
+	}
+}
+
+func TestTieredCache_Conformance(t *testing.T) {
+	mr, client := newMiniredis(t)
+
+	cachetest.Run(t, cachetest.Harness{
+		New: func(t *testing.T, cfg *cache.Config) cache.Cache {
+			tc, err := cache.NewTieredCache(nil, cache.NewLocalCache(&cache.Config{}), cache.NewRedisCache(cfg, client))
+			if err != nil {
+				t.Fatalf("NewTieredCache: %v", err)
+			}
+			t.Cleanup(func() { _ = tc.Close() })
+			return tc
+		},
+		Advance: func(d time.Duration) {
+			// near cache memakai jam asli, far cache memakai jam miniredis
+			mr.FastForward(d)
+			time.Sleep(d)
+		},
+	})
+}
@@ -0,0 +1,329 @@
+// Package cachetest berisi conformance test untuk implementasi cache.Cache.
+// Semua backend (termasuk implementasi custom) sebaiknya lulus Run.
+package cachetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/cache"
+)
+
+// Harness menyiapkan backend yang akan dites.
+type Harness struct {
+	// New membuat Cache baru dengan config yang diberikan.
+	// Untuk backend bersama (Redis), semua Cache dari Harness yang sama harus memakai storage yang sama.
+	New func(t *testing.T, cfg *cache.Config) cache.Cache
+
+	// Advance memajukan waktu backend, misal miniredis.FastForward.
+	// Kalau nil, pakai time.Sleep.
+	Advance func(d time.Duration)
+}
+
+type stringer struct{}
+
+func (stringer) String() string { return "from stringer" }
+
+// Run menjalankan seluruh conformance test terhadap Harness.
+func Run(t *testing.T, h Harness) {
+	t.Helper()
+
+	advance := h.Advance
+	if advance == nil {
+		advance = time.Sleep
+	}
+
+	newCache := func(t *testing.T) cache.Cache {
+		return h.New(t, &cache.Config{Prefix: "conformance:"})
+	}
+
+	t.Run("GetMissingReturnsErrNotFound", func(t *testing.T) {
+		c := newCache(t)
+
+		_, err := c.Get(context.Background(), "missing")
+		if !errors.Is(err, cache.ErrNotFound) {
+			t.Fatalf("Get error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("SetThenGet", func(t *testing.T) {
+		c := newCache(t)
+		ctx := context.Background()
+
+		if err := c.Set(ctx, "name", "John", 60); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+
+		assertGet(t, c, "name", "John")
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		c := newCache(t)
+		ctx := context.Background()
+
+		_ = c.Set(ctx, "name", "John", 60)
+		_ = c.Set(ctx, "name", "Jane", 60)
+
+		assertGet(t, c, "name", "Jane")
+	})
+
+	t.Run("ValueConversion", func(t *testing.T) {
+		c := newCache(t)
+		ctx := context.Background()
+
+		cases := []struct {
+			key   string
+			value any
+			want  string
+		}{
+			{"int", 42, "42"},
+			{"float", 1.5, "1.5"},
+			{"bool", true, "true"},
+			{"bytes", []byte("raw"), "raw"},
+			{"stringer", stringer{}, "from stringer"},
+		}
+
+		for _, tc := range cases {
+			if err := c.Set(ctx, tc.key, tc.value, 60); err != nil {
+				t.Fatalf("Set(%s): %v", tc.key, err)
+			}
+			assertGet(t, c, tc.key, tc.want)
+		}
+	})
+
+	t.Run("Has", func(t *testing.T) {
+		c := newCache(t)
+		ctx := context.Background()
+
+		_ = c.Set(ctx, "exists", "yes", 60)
+
+		assertHas(t, c, "exists", true)
+		assertHas(t, c, "missing", false)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		c := newCache(t)
+		ctx := context.Background()
+
+		_ = c.Set(ctx, "name", "John", 60)
+		if err := c.Delete(ctx, "name"); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+
+		assertHas(t, c, "name", false)
+		if _, err := c.Get(ctx, "name"); !errors.Is(err, cache.ErrNotFound) {
+			t.Fatalf("Get after Delete error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("DeleteMissingIsNotError", func(t *testing.T) {
+		c := newCache(t)
+
+		if err := c.Delete(context.Background(), "missing"); err != nil {
+			t.Fatalf("Delete missing key: %v", err)
+		}
+	})
+
+	t.Run("TTLExpires", func(t *testing.T) {
+		c := newCache(t)
+		ctx := context.Background()
+
+		_ = c.Set(ctx, "temp", "value", 1)
+		assertHas(t, c, "temp", true)
+
+		advance(1100 * time.Millisecond)
+
+		assertHas(t, c, "temp", false)
+		if _, err := c.Get(ctx, "temp"); !errors.Is(err, cache.ErrNotFound) {
+			t.Fatalf("Get expired key error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("NonPositiveTTLNeverExpires", func(t *testing.T) {
+		c := newCache(t)
+		ctx := context.Background()
+
+		if err := c.Set(ctx, "zero", "value", 0); err != nil {
+			t.Fatalf("Set ttl=0: %v", err)
+		}
+		if err := c.Set(ctx, "negative", "value", -1); err != nil {
+			t.Fatalf("Set ttl=-1: %v", err)
+		}
+
+		advance(1100 * time.Millisecond)
+
+		assertGet(t, c, "zero", "value")
+		assertGet(t, c, "negative", "value")
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		c := newCache(t)
+		ctx := context.Background()
+
+		err := cache.SetMany(ctx, c, map[string]any{"a": "1", "b": 2}, 60)
+		if err != nil {
+			t.Fatalf("SetMany: %v", err)
+		}
+
+		got, err := cache.GetMany(ctx, c, []string{"a", "b", "missing"})
+		if err != nil {
+			t.Fatalf("GetMany: %v", err)
+		}
+		if len(got) != 2 || got["a"] != "1" || got["b"] != "2" {
+			t.Fatalf("GetMany = %v, want map[a:1 b:2]", got)
+		}
+		if _, ok := got["missing"]; ok {
+			t.Fatal("GetMany: missing key reported as hit")
+		}
+
+		if err := cache.DeleteMany(ctx, c, []string{"a", "b", "missing"}); err != nil {
+			t.Fatalf("DeleteMany: %v", err)
+		}
+		assertHas(t, c, "a", false)
+		assertHas(t, c, "b", false)
+	})
+
+	t.Run("InvalidateTags", func(t *testing.T) {
+		c := newCache(t)
+		tc, ok := c.(cache.TagCache)
+		if !ok {
+			t.Skip("cache does not implement TagCache")
+		}
+		ctx := context.Background()
+
+		_ = tc.SetWithTags(ctx, "user:42:profile", "p", 60, "user:42")
+		_ = tc.SetWithTags(ctx, "user:42:orders", "o", 0, "user:42", "orders")
+		_ = tc.SetWithTags(ctx, "user:7:profile", "p", 60, "user:7")
+
+		if err := tc.InvalidateTags(ctx, "user:42"); err != nil {
+			t.Fatalf("InvalidateTags: %v", err)
+		}
+
+		assertHas(t, c, "user:42:profile", false)
+		assertHas(t, c, "user:42:orders", false)
+		assertHas(t, c, "user:7:profile", true)
+
+		if err := tc.InvalidateTags(ctx, "unknown"); err != nil {
+			t.Fatalf("InvalidateTags unknown tag: %v", err)
+		}
+	})
+
+	t.Run("FlushScopedToPrefix", func(t *testing.T) {
+		a := h.New(t, &cache.Config{Prefix: "flush-a:"})
+		b := h.New(t, &cache.Config{Prefix: "flush-b:"})
+		f, ok := a.(cache.Flusher)
+		if !ok {
+			t.Skip("cache does not implement Flusher")
+		}
+		ctx := context.Background()
+
+		_ = a.Set(ctx, "one", "1", 0)
+		_ = a.Set(ctx, "two", "2", 60)
+		_ = b.Set(ctx, "one", "1", 0)
+
+		if err := f.Flush(ctx); err != nil {
+			t.Fatalf("Flush: %v", err)
+		}
+
+		assertHas(t, a, "one", false)
+		assertHas(t, a, "two", false)
+		assertHas(t, b, "one", true)
+	})
+
+	t.Run("Atomic", func(t *testing.T) {
+		c := newCache(t)
+		ac, ok := c.(cache.AtomicCache)
+		if !ok {
+			t.Skip("cache does not implement AtomicCache")
+		}
+		ctx := context.Background()
+
+		if v, err := ac.Increment(ctx, "counter", 5, 1); err != nil || v != 5 {
+			t.Fatalf("Increment new key = %d, %v, want 5", v, err)
+		}
+		// TTL hanya dipasang saat key dibuat, increment berikutnya tidak memperpanjang
+		if v, err := ac.Increment(ctx, "counter", 2, 60); err != nil || v != 7 {
+			t.Fatalf("Increment = %d, %v, want 7", v, err)
+		}
+		if v, err := ac.Decrement(ctx, "counter", 3, 60); err != nil || v != 4 {
+			t.Fatalf("Decrement = %d, %v, want 4", v, err)
+		}
+
+		_ = c.Set(ctx, "text", "abc", 0)
+		if _, err := ac.Increment(ctx, "text", 1, 0); !errors.Is(err, cache.ErrNotInteger) {
+			t.Fatalf("Increment non-integer error = %v, want ErrNotInteger", err)
+		}
+
+		if ok, err := ac.SetNX(ctx, "once", "first", 0); err != nil || !ok {
+			t.Fatalf("SetNX new key = %v, %v, want true", ok, err)
+		}
+		if ok, err := ac.SetNX(ctx, "once", "second", 0); err != nil || ok {
+			t.Fatalf("SetNX existing key = %v, %v, want false", ok, err)
+		}
+		assertGet(t, c, "once", "first")
+
+		if ok, err := ac.CompareAndSwap(ctx, "once", "wrong", "x"); err != nil || ok {
+			t.Fatalf("CompareAndSwap mismatch = %v, %v, want false", ok, err)
+		}
+		if ok, err := ac.CompareAndSwap(ctx, "once", "first", "second"); err != nil || !ok {
+			t.Fatalf("CompareAndSwap match = %v, %v, want true", ok, err)
+		}
+		assertGet(t, c, "once", "second")
+		if ok, err := ac.CompareAndSwap(ctx, "missing", "", "x"); err != nil || ok {
+			t.Fatalf("CompareAndSwap missing key = %v, %v, want false", ok, err)
+		}
+
+		advance(1100 * time.Millisecond)
+		assertHas(t, c, "counter", false)
+	})
+
+	t.Run("PrefixIsolation", func(t *testing.T) {
+		a := h.New(t, &cache.Config{Prefix: "tenant-a:"})
+		b := h.New(t, &cache.Config{Prefix: "tenant-b:"})
+		ctx := context.Background()
+
+		_ = a.Set(ctx, "name", "A", 60)
+
+		assertGet(t, a, "name", "A")
+		assertHas(t, b, "name", false)
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		c := newCache(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if err := c.Set(ctx, "name", "John", 60); err == nil {
+			t.Fatal("Set with canceled context: want error")
+		}
+		if _, err := c.Get(ctx, "name"); err == nil {
+			t.Fatal("Get with canceled context: want error")
+		}
+	})
+}
+
+func assertGet(t *testing.T, c cache.Cache, key, want string) {
+	t.Helper()
+
+	got, err := c.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%q): %v", key, err)
+	}
+	if got != want {
+		t.Fatalf("Get(%q) = %q, want %q", key, got, want)
+	}
+}
+
+func assertHas(t *testing.T, c cache.Cache, key string, want bool) {
+	t.Helper()
+
+	got, err := c.Has(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Has(%q): %v", key, err)
+	}
+	if got != want {
+		t.Fatalf("Has(%q) = %v, want %v", key, got, want)
+	}
+}
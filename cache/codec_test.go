@@ -0,0 +1,39 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/fatkulnurk/foundation/cache"
+)
+
+func TestCodecs(t *testing.T) {
+	codecs := map[string]cache.Codec{
+		"json":    cache.JSONCodec{},
+		"gob":     cache.GobCodec{},
+		"msgpack": cache.MsgpackCodec{},
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			in := map[string]int{"a": 1, "b": 2}
+			data, err := codec.Marshal(in)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+
+			var out map[string]int
+			if err := codec.Unmarshal(data, &out); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if len(out) != 2 || out["a"] != 1 || out["b"] != 2 {
+				t.Fatalf("Unmarshal = %v", out)
+			}
+
+			// Data rusak / terpotong harus menghasilkan error, bukan nilai kosong
+			var broken map[string]int
+			if err := codec.Unmarshal(data[:len(data)-1], &broken); err == nil {
+				t.Fatalf("Unmarshal truncated data: expected error, got %v", broken)
+			}
+		})
+	}
+}
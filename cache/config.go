@@ -1,13 +1,51 @@
 package cache
 
-import "github.com/fatkulnurk/foundation/support"
+import (
+	"time"
+
+	"github.com/fatkulnurk/foundation/support"
+)
+
+// EvictionPolicy menentukan item mana yang dibuang LocalCache saat batas tercapai.
+type EvictionPolicy string
+
+const (
+	// EvictLRU membuang item yang paling lama tidak diakses (default).
+	EvictLRU EvictionPolicy = "lru"
+
+	// EvictLFU membuang item yang paling jarang diakses.
+	EvictLFU EvictionPolicy = "lfu"
+)
 
 type Config struct {
 	Prefix string
+
+	// Field di bawah ini hanya dipakai LocalCache.
+
+	// MaxEntries adalah jumlah maksimal item. 0 = tanpa batas.
+	MaxEntries int
+
+	// MaxBytes adalah total maksimal panjang key + value. 0 = tanpa batas.
+	MaxBytes int64
+
+	// EvictionPolicy dipakai saat MaxEntries / MaxBytes terlampaui. Default EvictLRU.
+	EvictionPolicy EvictionPolicy
+
+	// CleanupInterval adalah interval janitor yang membersihkan item expired.
+	// 0 = tidak ada janitor, item expired hanya dihapus saat dibaca atau tergusur.
+	CleanupInterval time.Duration
+
+	// OnEvict dipanggil setiap kali item dibuang karena expired atau batas kapasitas.
+	// Tidak dipanggil untuk Delete.
+	OnEvict EvictFunc
 }
 
 func LoadConfig() *Config {
 	return &Config{
-		Prefix: support.GetEnv("CACHE_PREFIX", ""), // example: foundation:
+		Prefix:          support.GetEnv("CACHE_PREFIX", ""), // example: foundation:
+		MaxEntries:      support.GetIntEnv("CACHE_LOCAL_MAX_ENTRIES", 0),
+		MaxBytes:        int64(support.GetIntEnv("CACHE_LOCAL_MAX_BYTES", 0)),
+		EvictionPolicy:  EvictionPolicy(support.GetEnv("CACHE_LOCAL_EVICTION_POLICY", string(EvictLRU))),
+		CleanupInterval: support.GetDurationEnv("CACHE_LOCAL_CLEANUP_INTERVAL", 0),
 	}
 }
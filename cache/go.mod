@@ -3,14 +3,24 @@ module github.com/fatkulnurk/foundation/cache
 go 1.25
 
 require (
+	github.com/alicebob/miniredis/v2 v2.37.0
+	github.com/fatkulnurk/foundation/logging v0.0.0-00010101000000-000000000000
 	github.com/fatkulnurk/foundation/support v0.0.0-00010101000000-000000000000
 	github.com/redis/go-redis/v9 v9.17.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	go.uber.org/mock v0.6.0
 )
 
-replace github.com/fatkulnurk/foundation/support => ../support
+replace (
+	github.com/fatkulnurk/foundation/logging => ../logging
+	github.com/fatkulnurk/foundation/support => ../support
+)
 
 require (
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
-	go.uber.org/mock v0.6.0 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	go.uber.org/zap v1.27.1 // indirect
 )
@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fatkulnurk/foundation/logging"
+)
+
+// Hasil operasi yang dilaporkan ke MetricsSink
+const (
+	ResultHit   = "hit"
+	ResultMiss  = "miss"
+	ResultOK    = "ok"
+	ResultError = "error"
+)
+
+// MetricsSink menerima hasil setiap operasi cache, misalnya untuk diteruskan ke Prometheus.
+// op adalah nama method ("get", "set", "delete", "has"), result salah satu konstanta Result*.
+type MetricsSink interface {
+	ObserveCacheOperation(op, result string, duration time.Duration)
+}
+
+// MetricsSinkFunc mengubah fungsi biasa menjadi MetricsSink.
+type MetricsSinkFunc func(op, result string, duration time.Duration)
+
+func (f MetricsSinkFunc) ObserveCacheOperation(op, result string, duration time.Duration) {
+	f(op, result, duration)
+}
+
+// InstrumentedCache membungkus Cache apa pun dan mencatat hit, miss, error dan durasi
+// setiap operasi ke MetricsSink, Stats, dan logger di level debug.
+//
+// Hanya method Cache yang diteruskan; interface opsional milik cache di dalamnya
+// (BatchCache, TagCache, AtomicCache, ...) tidak ikut terlihat dari luar.
+type InstrumentedCache struct {
+	cache  Cache
+	sink   MetricsSink
+	logger logging.Logger
+	stats  statsCounter
+}
+
+// Compile-time check
+var _ Cache = (*InstrumentedCache)(nil)
+
+// NewInstrumentedCache membungkus c. sink dan logger boleh nil.
+func NewInstrumentedCache(c Cache, sink MetricsSink, logger logging.Logger) *InstrumentedCache {
+	return &InstrumentedCache{cache: c, sink: sink, logger: logger}
+}
+
+func (i *InstrumentedCache) Set(ctx context.Context, key string, value any, ttlSeconds int) error {
+	start := time.Now()
+	err := i.cache.Set(ctx, key, value, ttlSeconds)
+
+	i.stats.record(&i.stats.sets, 1, err)
+	i.observe(ctx, "set", key, start, resultOf(err), err)
+	return err
+}
+
+func (i *InstrumentedCache) Get(ctx context.Context, key string) (string, error) {
+	start := time.Now()
+	value, err := i.cache.Get(ctx, key)
+
+	i.stats.recordGet(err)
+
+	result := ResultHit
+	switch {
+	case errors.Is(err, ErrNotFound):
+		result = ResultMiss
+	case err != nil:
+		result = ResultError
+	}
+	i.observe(ctx, "get", key, start, result, err)
+	return value, err
+}
+
+func (i *InstrumentedCache) Delete(ctx context.Context, key string) error {
+	start := time.Now()
+	err := i.cache.Delete(ctx, key)
+
+	i.stats.record(&i.stats.deletes, 1, err)
+	i.observe(ctx, "delete", key, start, resultOf(err), err)
+	return err
+}
+
+func (i *InstrumentedCache) Has(ctx context.Context, key string) (bool, error) {
+	start := time.Now()
+	ok, err := i.cache.Has(ctx, key)
+
+	i.stats.fail(err)
+
+	result := ResultMiss
+	switch {
+	case err != nil:
+		result = ResultError
+	case ok:
+		result = ResultHit
+	}
+	i.observe(ctx, "has", key, start, result, err)
+	return ok, err
+}
+
+// Stats mengembalikan statistik operasi yang lewat wrapper ini.
+func (i *InstrumentedCache) Stats() Stats {
+	return i.stats.snapshot()
+}
+
+// Unwrap mengembalikan Cache yang dibungkus.
+func (i *InstrumentedCache) Unwrap() Cache {
+	return i.cache
+}
+
+func (i *InstrumentedCache) observe(ctx context.Context, op, key string, start time.Time, result string, err error) {
+	duration := time.Since(start)
+
+	if i.sink != nil {
+		i.sink.ObserveCacheOperation(op, result, duration)
+	}
+
+	if i.logger != nil {
+		fields := []logging.Field{
+			logging.NewField("key", key),
+			logging.NewField("result", result),
+			logging.NewField("duration", duration),
+		}
+		if err != nil && result == ResultError {
+			fields = append(fields, logging.NewField("error", err.Error()))
+		}
+		i.logger.Debug(ctx, "cache "+op, fields...)
+	}
+}
+
+// Helper: hasil operasi tulis
+func resultOf(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultOK
+}
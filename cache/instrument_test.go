@@ -0,0 +1,69 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/cache"
+)
+
+func TestInstrumentedCache_ReportsOperations(t *testing.T) {
+	type observation struct{ op, result string }
+	var got []observation
+
+	sink := cache.MetricsSinkFunc(func(op, result string, duration time.Duration) {
+		got = append(got, observation{op, result})
+	})
+
+	c := cache.NewInstrumentedCache(cache.NewLocalCache(&cache.Config{}), sink, nil)
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", "1", 0)
+	_, _ = c.Get(ctx, "a")
+	_, _ = c.Get(ctx, "missing")
+	_ = c.Delete(ctx, "a")
+
+	want := []observation{{"set", "ok"}, {"get", "hit"}, {"get", "miss"}, {"delete", "ok"}}
+	if len(got) != len(want) {
+		t.Fatalf("observations = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("observation[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Sets != 1 || stats.Deletes != 1 {
+		t.Errorf("Stats = %+v", stats)
+	}
+	if ratio := stats.HitRatio(); ratio != 0.5 {
+		t.Errorf("HitRatio = %v, want 0.5", ratio)
+	}
+}
+
+func TestLocalCache_Stats(t *testing.T) {
+	c := cache.NewLocalCache(&cache.Config{MaxEntries: 1})
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", "1", 0)
+	_ = c.Set(ctx, "b", "2", 0) // a tergusur
+	_, _ = c.Get(ctx, "a")
+	_, _ = c.Get(ctx, "b")
+
+	stats := c.(cache.StatsProvider).Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Evictions != 1 || stats.Expirations != 0 || stats.Entries != 1 {
+		t.Errorf("Stats = %+v", stats)
+	}
+
+	// TTL habis dihitung sebagai expiration, bukan eviction
+	_ = c.Set(ctx, "c", "3", 1) // b tergusur
+	time.Sleep(1100 * time.Millisecond)
+	_, _ = c.Get(ctx, "c")
+
+	stats = c.(cache.StatsProvider).Stats()
+	if stats.Evictions != 2 || stats.Expirations != 1 || stats.Entries != 0 {
+		t.Errorf("Stats after expiry = %+v", stats)
+	}
+}
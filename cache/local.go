@@ -1,33 +1,93 @@
 package cache
 
 import (
+	"container/heap"
 	"context"
-	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
-var (
-	ErrNotFound = errors.New("key not found")
+// EvictReason menjelaskan kenapa item dibuang dari LocalCache.
+type EvictReason int
+
+const (
+	EvictReasonExpired EvictReason = iota + 1
+	EvictReasonCapacity
 )
 
+func (r EvictReason) String() string {
+	switch r {
+	case EvictReasonExpired:
+		return "expired"
+	case EvictReasonCapacity:
+		return "capacity"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictFunc adalah callback eviction. key tanpa prefix.
+type EvictFunc func(key, value string, reason EvictReason)
+
 type item struct {
+	key       string
 	value     string
 	expiresAt time.Time // zero value (time.Time{}) berarti tidak ada TTL
+	tags      []string
+
+	// untuk eviction
+	lastAccess uint64 // nilai tick saat terakhir diakses
+	hits       uint64
+	index      int // posisi di heap
+	expIndex   int // posisi di expiryHeap, -1 kalau tidak ada TTL
+}
+
+func (it *item) size() int64 {
+	return int64(len(it.key) + len(it.value))
+}
+
+type evicted struct {
+	key    string
+	value  string
+	reason EvictReason
 }
 
 type LocalCache struct {
 	cfg   *Config
-	mu    sync.RWMutex
-	items map[string]item
+	mu    sync.Mutex
+	items map[string]*item
+	tags  map[string]map[string]struct{} // tag -> key
+	order evictionHeap
+	exp   expiryHeap // item dengan TTL, yang paling cepat expired di root
+	tick  uint64
+	bytes int64
+
+	stop      chan struct{}
+	closeOnce sync.Once
+
+	flight flightGroup
+	stats  statsCounter
 }
 
+// NewLocalCache membuat cache in-memory.
+// Kalau cfg.CleanupInterval > 0, janitor berjalan di background sampai Close dipanggil.
 func NewLocalCache(cfg *Config) Cache {
-	return &LocalCache{
+	c := &LocalCache{
 		cfg:   cfg,
-		items: make(map[string]item),
+		items: make(map[string]*item),
+		tags:  make(map[string]map[string]struct{}),
+		order: evictionHeap{lfu: cfg.EvictionPolicy == EvictLFU},
+		stop:  make(chan struct{}),
+	}
+
+	if cfg.CleanupInterval > 0 {
+		go c.janitor(cfg.CleanupInterval)
 	}
+
+	return c
 }
 
 // Set menyimpan key dengan TTL dalam detik.
@@ -44,19 +104,14 @@ func (c *LocalCache) Set(ctx context.Context, key string, value any, ttlSeconds
 		return err
 	}
 
-	var expiresAt time.Time
-	if ttlSeconds > 0 {
-		expiresAt = time.Now().Add(time.Duration(ttlSeconds) * time.Second)
-	}
+	expiresAt := expiry(ttlSeconds)
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.items[key] = item{
-		value:     stringValue,
-		expiresAt: expiresAt,
-	}
+	out := c.store(key, stringValue, expiresAt)
+	c.mu.Unlock()
 
+	c.stats.sets.Add(1)
+	c.notify(out)
 	return nil
 }
 
@@ -67,24 +122,24 @@ func (c *LocalCache) Get(ctx context.Context, key string) (string, error) {
 
 	key = c.cfg.Prefix + key
 
-	c.mu.RLock()
-	it, ok := c.items[key]
-	c.mu.RUnlock()
+	var out []evicted
 
+	c.mu.Lock()
+	it, ok := c.lookup(key, &out) // item expired ikut dihapus
 	if !ok {
-		return "", ErrNotFound
-	}
-
-	// Cek expired
-	if isExpired(it.expiresAt) {
-		// Hapus kalau expired
-		c.mu.Lock()
-		delete(c.items, key)
 		c.mu.Unlock()
+
+		c.stats.misses.Add(1)
+		c.notify(out)
 		return "", ErrNotFound
 	}
 
-	return it.value, nil
+	c.touch(it)
+	value := it.value
+	c.mu.Unlock()
+
+	c.stats.hits.Add(1)
+	return value, nil
 }
 
 func (c *LocalCache) Delete(ctx context.Context, key string) error {
@@ -97,7 +152,11 @@ func (c *LocalCache) Delete(ctx context.Context, key string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	delete(c.items, key)
+	if it, ok := c.items[key]; ok {
+		c.remove(it)
+	}
+
+	c.stats.deletes.Add(1)
 	return nil
 }
 
@@ -108,25 +167,565 @@ func (c *LocalCache) Has(ctx context.Context, key string) (bool, error) {
 
 	key = c.cfg.Prefix + key
 
-	c.mu.RLock()
+	c.mu.Lock()
 	it, ok := c.items[key]
-	c.mu.RUnlock()
-
 	if !ok {
+		c.mu.Unlock()
 		return false, nil
 	}
 
 	if isExpired(it.expiresAt) {
 		// Bersihkan kalau sudah kadaluwarsa
-		c.mu.Lock()
-		delete(c.items, key)
+		c.remove(it)
 		c.mu.Unlock()
+
+		c.notify([]evicted{{key: it.key, value: it.value, reason: EvictReasonExpired}})
 		return false, nil
 	}
+	c.mu.Unlock()
 
 	return true, nil
 }
 
+// GetMany membaca banyak key dengan satu kali lock. Map hasil hanya berisi key yang ditemukan.
+func (c *LocalCache) GetMany(ctx context.Context, keys []string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(keys))
+	var out []evicted
+
+	c.mu.Lock()
+	for _, key := range keys {
+		it, ok := c.items[c.cfg.Prefix+key]
+		if !ok {
+			continue
+		}
+
+		if isExpired(it.expiresAt) {
+			c.remove(it)
+			out = append(out, evicted{key: it.key, value: it.value, reason: EvictReasonExpired})
+			continue
+		}
+
+		c.touch(it)
+		result[key] = it.value
+	}
+	c.mu.Unlock()
+
+	c.stats.hits.Add(uint64(len(result)))
+	c.stats.misses.Add(uint64(len(keys) - len(result)))
+	c.notify(out)
+	return result, nil
+}
+
+// SetMany menyimpan banyak key dengan satu kali lock.
+func (c *LocalCache) SetMany(ctx context.Context, values map[string]any, ttlSeconds int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Konversi di luar lock, dan gagal sebelum ada yang tersimpan
+	stringValues := make(map[string]string, len(values))
+	for key, value := range values {
+		stringValue, err := toString(value)
+		if err != nil {
+			return err
+		}
+		stringValues[c.cfg.Prefix+key] = stringValue
+	}
+
+	expiresAt := expiry(ttlSeconds)
+
+	var out []evicted
+
+	c.mu.Lock()
+	for key, value := range stringValues {
+		out = append(out, c.store(key, value, expiresAt)...)
+	}
+	c.mu.Unlock()
+
+	c.stats.sets.Add(uint64(len(stringValues)))
+	c.notify(out)
+	return nil
+}
+
+// DeleteMany menghapus banyak key dengan satu kali lock.
+func (c *LocalCache) DeleteMany(ctx context.Context, keys []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if it, ok := c.items[c.cfg.Prefix+key]; ok {
+			c.remove(it)
+		}
+	}
+
+	c.stats.deletes.Add(uint64(len(keys)))
+	return nil
+}
+
+// SetWithTags seperti Set, tapi key juga didaftarkan ke setiap tag.
+// Tag yang sudah ada di key sebelumnya tetap berlaku sampai key dihapus.
+func (c *LocalCache) SetWithTags(ctx context.Context, key string, value any, ttlSeconds int, tags ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	key = c.cfg.Prefix + key
+
+	stringValue, err := toString(value)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := expiry(ttlSeconds)
+
+	c.mu.Lock()
+	out := c.store(key, stringValue, expiresAt)
+	if it, ok := c.items[key]; ok {
+		c.addTags(it, tags)
+	}
+	c.mu.Unlock()
+
+	c.stats.sets.Add(1)
+	c.notify(out)
+	return nil
+}
+
+// InvalidateTags menghapus semua key yang punya salah satu tag.
+func (c *LocalCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if it, ok := c.items[key]; ok {
+				c.remove(it)
+			}
+		}
+		delete(c.tags, tag)
+	}
+	return nil
+}
+
+// Flush menghapus semua item. Semua key di LocalCache memakai prefix yang sama,
+// jadi ini sama dengan membersihkan seluruh isi cache.
+func (c *LocalCache) Flush(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*item)
+	c.tags = make(map[string]map[string]struct{})
+	c.order.items = nil
+	c.exp = nil
+	c.bytes = 0
+	return nil
+}
+
+// Increment menambah nilai integer key di bawah lock. Key baru dibuat dengan ttlSeconds,
+// key lama tetap memakai expiry-nya.
+func (c *LocalCache) Increment(ctx context.Context, key string, delta int64, ttlSeconds int) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	key = c.cfg.Prefix + key
+
+	c.mu.Lock()
+	var out []evicted
+	var value int64
+
+	it, ok := c.lookup(key, &out)
+	if ok {
+		current, err := strconv.ParseInt(it.value, 10, 64)
+		if err != nil {
+			c.mu.Unlock()
+			c.notify(out)
+			return 0, ErrNotInteger
+		}
+		value = current + delta
+		out = append(out, c.store(key, strconv.FormatInt(value, 10), it.expiresAt)...)
+	} else {
+		value = delta
+		out = append(out, c.store(key, strconv.FormatInt(value, 10), expiry(ttlSeconds))...)
+	}
+	c.mu.Unlock()
+
+	c.notify(out)
+	return value, nil
+}
+
+func (c *LocalCache) Decrement(ctx context.Context, key string, delta int64, ttlSeconds int) (int64, error) {
+	return c.Increment(ctx, key, -delta, ttlSeconds)
+}
+
+// SetNX menyimpan value hanya kalau key belum ada (atau sudah expired).
+func (c *LocalCache) SetNX(ctx context.Context, key string, value any, ttlSeconds int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	key = c.cfg.Prefix + key
+
+	stringValue, err := toString(value)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	var out []evicted
+
+	_, exists := c.lookup(key, &out)
+	if !exists {
+		out = append(out, c.store(key, stringValue, expiry(ttlSeconds))...)
+	}
+	c.mu.Unlock()
+
+	c.notify(out)
+	return !exists, nil
+}
+
+// CompareAndSwap mengganti value kalau value sekarang sama dengan old. Expiry tidak berubah.
+func (c *LocalCache) CompareAndSwap(ctx context.Context, key string, old, new any) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	key = c.cfg.Prefix + key
+
+	oldValue, err := toString(old)
+	if err != nil {
+		return false, err
+	}
+	newValue, err := toString(new)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	var out []evicted
+
+	it, ok := c.lookup(key, &out)
+	swapped := ok && it.value == oldValue
+	if swapped {
+		out = append(out, c.store(key, newValue, it.expiresAt)...)
+	}
+	c.mu.Unlock()
+
+	c.notify(out)
+	return swapped, nil
+}
+
+// Remember mengambil key, atau menjalankan loader sekali saja walaupun dipanggil
+// banyak goroutine bersamaan. Opsi WithLock diabaikan karena cache ini hanya ada di satu proses.
+func (c *LocalCache) Remember(ctx context.Context, key string, ttlSeconds int, loader Loader, opts ...RememberOption) (string, error) {
+	value, err := c.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+	if !isNotFound(err) {
+		return "", err
+	}
+
+	return c.flight.do(ctx, key, func() (string, error) {
+		return loadAndSet(ctx, c, key, ttlSeconds, loader)
+	})
+}
+
+// Stats mengembalikan snapshot statistik cache.
+func (c *LocalCache) Stats() Stats {
+	stats := c.stats.snapshot()
+
+	c.mu.Lock()
+	stats.Entries = len(c.items)
+	stats.Bytes = c.bytes
+	c.mu.Unlock()
+
+	return stats
+}
+
+// Len mengembalikan jumlah item yang tersimpan, termasuk yang expired tapi belum dibersihkan.
+func (c *LocalCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Close menghentikan janitor. Aman dipanggil lebih dari sekali.
+func (c *LocalCache) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.stop)
+	})
+	return nil
+}
+
+// =============== INTERNAL (dipanggil dengan c.mu terkunci) ===============
+
+// lookup mencari item yang belum expired. Item expired dibuang dan dicatat ke out.
+func (c *LocalCache) lookup(key string, out *[]evicted) (*item, bool) {
+	it, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	if isExpired(it.expiresAt) {
+		c.remove(it)
+		*out = append(*out, evicted{key: it.key, value: it.value, reason: EvictReasonExpired})
+		return nil, false
+	}
+
+	return it, true
+}
+
+// store menyimpan item dan mengembalikan item yang tergusur untuk dilaporkan ke OnEvict.
+func (c *LocalCache) store(key, value string, expiresAt time.Time) []evicted {
+	if it, ok := c.items[key]; ok {
+		c.bytes -= it.size()
+		it.value = value
+		c.setExpiry(it, expiresAt)
+		c.bytes += it.size()
+		c.touch(it)
+		return c.evict(0, 0)
+	}
+
+	c.tick++
+	it := &item{
+		key:        key,
+		value:      value,
+		lastAccess: c.tick,
+		hits:       1,
+		expIndex:   -1,
+	}
+
+	// Buat ruang dulu baru masukkan item, supaya item baru tidak langsung tergusur di LFU
+	out := c.evict(1, it.size())
+
+	c.items[key] = it
+	c.bytes += it.size()
+	heap.Push(&c.order, it)
+	c.setExpiry(it, expiresAt)
+	return out
+}
+
+// setExpiry mengganti expiresAt dan menjaga posisi item di expiryHeap
+func (c *LocalCache) setExpiry(it *item, expiresAt time.Time) {
+	it.expiresAt = expiresAt
+	switch {
+	case expiresAt.IsZero():
+		if it.expIndex >= 0 {
+			heap.Remove(&c.exp, it.expIndex)
+		}
+	case it.expIndex >= 0:
+		heap.Fix(&c.exp, it.expIndex)
+	default:
+		heap.Push(&c.exp, it)
+	}
+}
+
+func (c *LocalCache) touch(it *item) {
+	c.tick++
+	it.lastAccess = c.tick
+	it.hits++
+	heap.Fix(&c.order, it.index)
+}
+
+func (c *LocalCache) remove(it *item) {
+	heap.Remove(&c.order, it.index)
+	if it.expIndex >= 0 {
+		heap.Remove(&c.exp, it.expIndex)
+	}
+	delete(c.items, it.key)
+	c.bytes -= it.size()
+
+	for _, tag := range it.tags {
+		delete(c.tags[tag], it.key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
+
+func (c *LocalCache) addTags(it *item, tags []string) {
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		if _, exists := keys[it.key]; !exists {
+			keys[it.key] = struct{}{}
+			it.tags = append(it.tags, tag)
+		}
+	}
+}
+
+// evict membuang item sampai masih ada ruang untuk extraEntries dan extraBytes tambahan.
+// Item expired dibuang lebih dulu sebelum item yang masih valid.
+func (c *LocalCache) evict(extraEntries int, extraBytes int64) []evicted {
+	if !c.overflow(extraEntries, extraBytes) {
+		return nil
+	}
+
+	out := c.removeExpired()
+	for c.overflow(extraEntries, extraBytes) && c.order.Len() > 0 {
+		it := c.order.items[0]
+		c.remove(it)
+		out = append(out, evicted{key: it.key, value: it.value, reason: EvictReasonCapacity})
+	}
+	return out
+}
+
+func (c *LocalCache) overflow(extraEntries int, extraBytes int64) bool {
+	if c.cfg.MaxEntries > 0 && len(c.items)+extraEntries > c.cfg.MaxEntries {
+		return true
+	}
+	if c.cfg.MaxBytes > 0 && c.bytes+extraBytes > c.cfg.MaxBytes {
+		return true
+	}
+	return false
+}
+
+// removeExpired membuang item expired dari root expiryHeap, jadi biayanya sebanding dengan
+// jumlah item yang expired, bukan jumlah seluruh item
+func (c *LocalCache) removeExpired() []evicted {
+	var out []evicted
+	now := time.Now()
+	for len(c.exp) > 0 && now.After(c.exp[0].expiresAt) {
+		it := c.exp[0]
+		c.remove(it)
+		out = append(out, evicted{key: it.key, value: it.value, reason: EvictReasonExpired})
+	}
+	return out
+}
+
+// notify mencatat eviction dan memanggil OnEvict di luar lock supaya callback boleh memakai cache lagi.
+func (c *LocalCache) notify(out []evicted) {
+	for _, e := range out {
+		if e.reason == EvictReasonExpired {
+			c.stats.expirations.Add(1)
+		} else {
+			c.stats.evictions.Add(1)
+		}
+	}
+
+	if c.cfg.OnEvict == nil {
+		return
+	}
+	for _, e := range out {
+		c.cfg.OnEvict(strings.TrimPrefix(e.key, c.cfg.Prefix), e.value, e.reason)
+	}
+}
+
+func (c *LocalCache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			out := c.removeExpired()
+			c.mu.Unlock()
+
+			c.notify(out)
+		}
+	}
+}
+
+// =============== EVICTION HEAP ===============
+
+// evictionHeap menyimpan item dengan kandidat eviction di root.
+// LRU: lastAccess terkecil. LFU: hits terkecil, kalau sama pakai lastAccess terkecil.
+type evictionHeap struct {
+	items []*item
+	lfu   bool
+}
+
+func (h evictionHeap) Len() int { return len(h.items) }
+
+func (h evictionHeap) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if h.lfu && a.hits != b.hits {
+		return a.hits < b.hits
+	}
+	return a.lastAccess < b.lastAccess
+}
+
+func (h evictionHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *evictionHeap) Push(x any) {
+	it := x.(*item)
+	it.index = len(h.items)
+	h.items = append(h.items, it)
+}
+
+func (h *evictionHeap) Pop() any {
+	old := h.items
+	n := len(old)
+	it := old[n-1]
+	old[n-1] = nil
+	h.items = old[:n-1]
+	it.index = -1
+	return it
+}
+
+// expiryHeap menyimpan item yang punya TTL dengan expiresAt terkecil di root
+type expiryHeap []*item
+
+func (h expiryHeap) Len() int { return len(h) }
+
+func (h expiryHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].expIndex = i
+	h[j].expIndex = j
+}
+
+func (h *expiryHeap) Push(x any) {
+	it := x.(*item)
+	it.expIndex = len(*h)
+	*h = append(*h, it)
+}
+
+func (h *expiryHeap) Pop() any {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	it.expIndex = -1
+	return it
+}
+
+// Helper: hitung waktu expired dari TTL detik, zero value kalau ttlSeconds <= 0
+func expiry(ttlSeconds int) time.Time {
+	if ttlSeconds <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(ttlSeconds) * time.Second)
+}
+
 // Helper: cek apakah sudah expired
 func isExpired(expiresAt time.Time) bool {
 	if expiresAt.IsZero() {
@@ -140,6 +739,8 @@ func toString(v any) (string, error) {
 	switch t := v.(type) {
 	case string:
 		return t, nil
+	case []byte:
+		return string(t), nil
 	case fmt.Stringer:
 		return t.String(), nil
 	default:
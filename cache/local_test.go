@@ -0,0 +1,128 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/cache"
+)
+
+func TestLocalCache_EvictLRU(t *testing.T) {
+	var evictedKeys []string
+	c := cache.NewLocalCache(&cache.Config{
+		MaxEntries: 2,
+		OnEvict: func(key, value string, reason cache.EvictReason) {
+			if reason != cache.EvictReasonCapacity {
+				t.Errorf("reason = %v, want capacity", reason)
+			}
+			evictedKeys = append(evictedKeys, key)
+		},
+	})
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", "1", 0)
+	_ = c.Set(ctx, "b", "2", 0)
+	_, _ = c.Get(ctx, "a") // a jadi yang terbaru
+	_ = c.Set(ctx, "c", "3", 0)
+
+	if len(evictedKeys) != 1 || evictedKeys[0] != "b" {
+		t.Fatalf("evicted = %v, want [b]", evictedKeys)
+	}
+	if ok, _ := c.Has(ctx, "a"); !ok {
+		t.Error("expected a to survive")
+	}
+}
+
+func TestLocalCache_EvictLFU(t *testing.T) {
+	c := cache.NewLocalCache(&cache.Config{MaxEntries: 2, EvictionPolicy: cache.EvictLFU})
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", "1", 0)
+	_ = c.Set(ctx, "b", "2", 0)
+	for i := 0; i < 3; i++ {
+		_, _ = c.Get(ctx, "a")
+	}
+	_, _ = c.Get(ctx, "b")
+	_ = c.Set(ctx, "c", "3", 0)
+
+	if ok, _ := c.Has(ctx, "b"); ok {
+		t.Error("expected b (least frequently used) to be evicted")
+	}
+	if ok, _ := c.Has(ctx, "a"); !ok {
+		t.Error("expected a to survive")
+	}
+}
+
+func TestLocalCache_MaxBytes(t *testing.T) {
+	c := cache.NewLocalCache(&cache.Config{MaxBytes: 10})
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", "1234", 0) // 5 bytes
+	_ = c.Set(ctx, "b", "1234", 0) // 10 bytes
+	_ = c.Set(ctx, "c", "1234", 0) // 15 bytes, a harus keluar
+
+	if ok, _ := c.Has(ctx, "a"); ok {
+		t.Error("expected a to be evicted")
+	}
+	if got := c.(*cache.LocalCache).Len(); got != 2 {
+		t.Errorf("Len = %d, want 2", got)
+	}
+}
+
+func TestLocalCache_Janitor(t *testing.T) {
+	var mu sync.Mutex
+	var reasons []cache.EvictReason
+
+	c := cache.NewLocalCache(&cache.Config{
+		CleanupInterval: 50 * time.Millisecond,
+		OnEvict: func(key, value string, reason cache.EvictReason) {
+			mu.Lock()
+			reasons = append(reasons, reason)
+			mu.Unlock()
+		},
+	})
+	defer c.(*cache.LocalCache).Close()
+
+	_ = c.Set(context.Background(), "temp", "value", 1)
+	time.Sleep(1200 * time.Millisecond)
+
+	if got := c.(*cache.LocalCache).Len(); got != 0 {
+		t.Fatalf("Len = %d, want 0 after janitor sweep", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reasons) != 1 || reasons[0] != cache.EvictReasonExpired {
+		t.Fatalf("reasons = %v, want [expired]", reasons)
+	}
+}
+
+func TestLocalCache_EvictsExpiredBeforeCapacity(t *testing.T) {
+	reasons := map[string]cache.EvictReason{}
+	c := cache.NewLocalCache(&cache.Config{
+		MaxEntries: 3,
+		OnEvict: func(key, value string, reason cache.EvictReason) {
+			reasons[key] = reason
+		},
+	})
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", "1", 1)
+	_ = c.Set(ctx, "b", "2", 0)
+	_ = c.Set(ctx, "c", "3", 1)
+	_ = c.Set(ctx, "c", "3", 0) // TTL dihapus, c tidak boleh ikut expired
+	time.Sleep(1100 * time.Millisecond)
+
+	_ = c.Set(ctx, "d", "4", 0)
+
+	if len(reasons) != 1 || reasons["a"] != cache.EvictReasonExpired {
+		t.Fatalf("evicted = %v, want only a as expired", reasons)
+	}
+	for _, key := range []string{"b", "c", "d"} {
+		if ok, _ := c.Has(ctx, key); !ok {
+			t.Errorf("expected %s to survive", key)
+		}
+	}
+}
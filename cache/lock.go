@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrLockNotAcquired dikembalikan TryLock kalau lock sedang dipegang pihak lain.
+	ErrLockNotAcquired = errors.New("cache: lock not acquired")
+
+	// ErrLockNotHeld dikembalikan Extend/Release kalau lock sudah expired atau diambil pihak lain.
+	ErrLockNotHeld = errors.New("cache: lock not held")
+
+	// ErrInvalidLockTTL dikembalikan TryLock, Lock dan Extend kalau ttl <= 0.
+	// Lock tanpa expiry tidak didukung karena lock milik proses yang mati tidak akan pernah lepas.
+	ErrInvalidLockTTL = errors.New("cache: lock ttl must be positive")
+)
+
+// releaseLockScript hanya menghapus lock kalau token-nya masih milik kita.
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// extendLockScript memperpanjang lock hanya kalau token-nya masih milik kita.
+// ARGV[2] = ttl dalam milidetik
+var extendLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Lock adalah lock yang sedang dipegang. Token membedakan pemilik lock, jadi
+// Release dan Extend dari pemegang lama (yang lock-nya sudah expired) tidak
+// akan mengganggu pemegang baru.
+type Lock struct {
+	key     string
+	token   string
+	backend lockBackend
+}
+
+// Key mengembalikan nama lock (tanpa prefix).
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Token mengembalikan token unik pemilik lock.
+func (l *Lock) Token() string {
+	return l.token
+}
+
+// Extend memperpanjang umur lock menjadi ttl dari sekarang.
+// Mengembalikan ErrLockNotHeld kalau lock sudah bukan milik kita.
+func (l *Lock) Extend(ctx context.Context, ttl time.Duration) error {
+	if ttl <= 0 {
+		return ErrInvalidLockTTL
+	}
+
+	ok, err := l.backend.extend(ctx, l.key, l.token, ttl)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Release melepas lock. Mengembalikan ErrLockNotHeld kalau lock sudah bukan milik kita.
+func (l *Lock) Release(ctx context.Context) error {
+	ok, err := l.backend.release(ctx, l.key, l.token)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// lockBackend adalah operasi dasar yang dibutuhkan locker.
+type lockBackend interface {
+	acquire(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	extend(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	release(ctx context.Context, key, token string) (bool, error)
+}
+
+type locker struct {
+	backend lockBackend
+}
+
+// NewRedisLocker membuat Locker di atas Redis. Key lock disimpan sebagai
+// "<cfg.Prefix>lock:<key>".
+func NewRedisLocker(cfg *Config, client *redis.Client) Locker {
+	return &locker{backend: &redisLockBackend{client: client, prefix: cfg.Prefix + "lock:"}}
+}
+
+// NewLocalLocker membuat Locker in-memory. Hanya berlaku dalam satu proses,
+// cocok untuk testing atau aplikasi single instance.
+func NewLocalLocker() Locker {
+	return &locker{backend: &localLockBackend{locks: make(map[string]localLock)}}
+}
+
+func (l *locker) TryLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidLockTTL
+	}
+
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := l.backend.acquire(ctx, key, token, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockNotAcquired
+	}
+
+	return &Lock{key: key, token: token, backend: l.backend}, nil
+}
+
+func (l *locker) Lock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	for {
+		lock, err := l.TryLock(ctx, key, ttl)
+		if !errors.Is(err, ErrLockNotAcquired) {
+			return lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// =============== REDIS ===============
+
+type redisLockBackend struct {
+	client *redis.Client
+	prefix string
+}
+
+func (b *redisLockBackend) acquire(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	return b.client.SetNX(ctx, b.prefix+key, token, ttl).Result()
+}
+
+func (b *redisLockBackend) extend(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	n, err := extendLockScript.Run(ctx, b.client, []string{b.prefix + key}, token, ttl.Milliseconds()).Int()
+	return n == 1, err
+}
+
+func (b *redisLockBackend) release(ctx context.Context, key, token string) (bool, error) {
+	n, err := releaseLockScript.Run(ctx, b.client, []string{b.prefix + key}, token).Int()
+	return n == 1, err
+}
+
+// =============== LOCAL ===============
+
+type localLock struct {
+	token     string
+	expiresAt time.Time
+}
+
+type localLockBackend struct {
+	mu    sync.Mutex
+	locks map[string]localLock
+}
+
+// held mengembalikan lock yang masih berlaku. Dipanggil dengan b.mu terkunci.
+func (b *localLockBackend) held(key string) (localLock, bool) {
+	l, ok := b.locks[key]
+	if !ok {
+		return localLock{}, false
+	}
+	if time.Now().After(l.expiresAt) {
+		delete(b.locks, key)
+		return localLock{}, false
+	}
+	return l, true
+}
+
+func (b *localLockBackend) acquire(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.held(key); ok {
+		return false, nil
+	}
+	b.locks[key] = localLock{token: token, expiresAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (b *localLockBackend) extend(ctx context.Context, key, token string, ttl time.Duration) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l, ok := b.held(key)
+	if !ok || l.token != token {
+		return false, nil
+	}
+	b.locks[key] = localLock{token: token, expiresAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (b *localLockBackend) release(ctx context.Context, key, token string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l, ok := b.held(key)
+	if !ok || l.token != token {
+		return false, nil
+	}
+	delete(b.locks, key)
+	return true, nil
+}
+
+// Helper: token acak untuk menandai pemilik lock
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,107 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/cache"
+)
+
+func TestLocker(t *testing.T) {
+	mr, client := newMiniredis(t)
+
+	lockers := map[string]struct {
+		locker  cache.Locker
+		advance func(d time.Duration)
+	}{
+		"local": {cache.NewLocalLocker(), time.Sleep},
+		"redis": {cache.NewRedisLocker(&cache.Config{Prefix: "app:"}, client), mr.FastForward},
+	}
+
+	for name, tc := range lockers {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			first, err := tc.locker.TryLock(ctx, "job", 200*time.Millisecond)
+			if err != nil {
+				t.Fatalf("TryLock: %v", err)
+			}
+
+			if _, err := tc.locker.TryLock(ctx, "job", time.Second); !errors.Is(err, cache.ErrLockNotAcquired) {
+				t.Fatalf("second TryLock error = %v, want ErrLockNotAcquired", err)
+			}
+
+			if err := first.Extend(ctx, 200*time.Millisecond); err != nil {
+				t.Fatalf("Extend: %v", err)
+			}
+
+			// Lock pertama expired, pemilik baru mengambil alih
+			tc.advance(300 * time.Millisecond)
+
+			second, err := tc.locker.TryLock(ctx, "job", time.Second)
+			if err != nil {
+				t.Fatalf("TryLock after expiry: %v", err)
+			}
+
+			if err := first.Release(ctx); !errors.Is(err, cache.ErrLockNotHeld) {
+				t.Fatalf("stale Release error = %v, want ErrLockNotHeld", err)
+			}
+			if err := first.Extend(ctx, time.Second); !errors.Is(err, cache.ErrLockNotHeld) {
+				t.Fatalf("stale Extend error = %v, want ErrLockNotHeld", err)
+			}
+
+			// Lock yang ditunggu dan context timeout
+			waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			if _, err := tc.locker.Lock(waitCtx, "job", time.Second); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Lock while held error = %v, want DeadlineExceeded", err)
+			}
+
+			if err := second.Release(ctx); err != nil {
+				t.Fatalf("Release: %v", err)
+			}
+
+			third, err := tc.locker.Lock(ctx, "job", time.Second)
+			if err != nil {
+				t.Fatalf("Lock after release: %v", err)
+			}
+			_ = third.Release(ctx)
+		})
+	}
+}
+
+func TestLocker_RejectsNonPositiveTTL(t *testing.T) {
+	_, client := newMiniredis(t)
+
+	lockers := map[string]cache.Locker{
+		"local": cache.NewLocalLocker(),
+		"redis": cache.NewRedisLocker(&cache.Config{Prefix: "app:"}, client),
+	}
+
+	for name, locker := range lockers {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for _, ttl := range []time.Duration{0, -time.Second} {
+				if _, err := locker.TryLock(ctx, "job", ttl); !errors.Is(err, cache.ErrInvalidLockTTL) {
+					t.Fatalf("TryLock(ttl=%v) error = %v, want ErrInvalidLockTTL", ttl, err)
+				}
+				if _, err := locker.Lock(ctx, "job", ttl); !errors.Is(err, cache.ErrInvalidLockTTL) {
+					t.Fatalf("Lock(ttl=%v) error = %v, want ErrInvalidLockTTL", ttl, err)
+				}
+			}
+
+			// Tidak ada lock yang tertinggal dari percobaan di atas
+			lock, err := locker.TryLock(ctx, "job", time.Second)
+			if err != nil {
+				t.Fatalf("TryLock: %v", err)
+			}
+			if err := lock.Extend(ctx, 0); !errors.Is(err, cache.ErrInvalidLockTTL) {
+				t.Fatalf("Extend(0) error = %v, want ErrInvalidLockTTL", err)
+			}
+			_ = lock.Release(ctx)
+		})
+	}
+}
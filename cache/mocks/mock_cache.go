@@ -12,7 +12,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	cache "github.com/fatkulnurk/foundation/cache"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -97,3 +99,390 @@ func (mr *MockCacheMockRecorder) Set(ctx, key, value, ttlSeconds any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), ctx, key, value, ttlSeconds)
 }
+
+// MockRememberer is a mock of Rememberer interface.
+type MockRememberer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemembererMockRecorder
+	isgomock struct{}
+}
+
+// MockRemembererMockRecorder is the mock recorder for MockRememberer.
+type MockRemembererMockRecorder struct {
+	mock *MockRememberer
+}
+
+// NewMockRememberer creates a new mock instance.
+func NewMockRememberer(ctrl *gomock.Controller) *MockRememberer {
+	mock := &MockRememberer{ctrl: ctrl}
+	mock.recorder = &MockRemembererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRememberer) EXPECT() *MockRemembererMockRecorder {
+	return m.recorder
+}
+
+// Remember mocks base method.
+func (m *MockRememberer) Remember(ctx context.Context, key string, ttlSeconds int, loader cache.Loader, opts ...cache.RememberOption) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, ttlSeconds, loader}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Remember", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Remember indicates an expected call of Remember.
+func (mr *MockRemembererMockRecorder) Remember(ctx, key, ttlSeconds, loader any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, ttlSeconds, loader}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remember", reflect.TypeOf((*MockRememberer)(nil).Remember), varargs...)
+}
+
+// MockBatchCache is a mock of BatchCache interface.
+type MockBatchCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchCacheMockRecorder is the mock recorder for MockBatchCache.
+type MockBatchCacheMockRecorder struct {
+	mock *MockBatchCache
+}
+
+// NewMockBatchCache creates a new mock instance.
+func NewMockBatchCache(ctrl *gomock.Controller) *MockBatchCache {
+	mock := &MockBatchCache{ctrl: ctrl}
+	mock.recorder = &MockBatchCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchCache) EXPECT() *MockBatchCacheMockRecorder {
+	return m.recorder
+}
+
+// DeleteMany mocks base method.
+func (m *MockBatchCache) DeleteMany(ctx context.Context, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockBatchCacheMockRecorder) DeleteMany(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockBatchCache)(nil).DeleteMany), ctx, keys)
+}
+
+// GetMany mocks base method.
+func (m *MockBatchCache) GetMany(ctx context.Context, keys []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, keys)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockBatchCacheMockRecorder) GetMany(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockBatchCache)(nil).GetMany), ctx, keys)
+}
+
+// SetMany mocks base method.
+func (m *MockBatchCache) SetMany(ctx context.Context, values map[string]any, ttlSeconds int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMany", ctx, values, ttlSeconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockBatchCacheMockRecorder) SetMany(ctx, values, ttlSeconds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockBatchCache)(nil).SetMany), ctx, values, ttlSeconds)
+}
+
+// MockTagCache is a mock of TagCache interface.
+type MockTagCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockTagCacheMockRecorder is the mock recorder for MockTagCache.
+type MockTagCacheMockRecorder struct {
+	mock *MockTagCache
+}
+
+// NewMockTagCache creates a new mock instance.
+func NewMockTagCache(ctrl *gomock.Controller) *MockTagCache {
+	mock := &MockTagCache{ctrl: ctrl}
+	mock.recorder = &MockTagCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagCache) EXPECT() *MockTagCacheMockRecorder {
+	return m.recorder
+}
+
+// InvalidateTags mocks base method.
+func (m *MockTagCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InvalidateTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateTags indicates an expected call of InvalidateTags.
+func (mr *MockTagCacheMockRecorder) InvalidateTags(ctx any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateTags", reflect.TypeOf((*MockTagCache)(nil).InvalidateTags), varargs...)
+}
+
+// SetWithTags mocks base method.
+func (m *MockTagCache) SetWithTags(ctx context.Context, key string, value any, ttlSeconds int, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, value, ttlSeconds}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetWithTags", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWithTags indicates an expected call of SetWithTags.
+func (mr *MockTagCacheMockRecorder) SetWithTags(ctx, key, value, ttlSeconds any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, value, ttlSeconds}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithTags", reflect.TypeOf((*MockTagCache)(nil).SetWithTags), varargs...)
+}
+
+// MockFlusher is a mock of Flusher interface.
+type MockFlusher struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlusherMockRecorder
+	isgomock struct{}
+}
+
+// MockFlusherMockRecorder is the mock recorder for MockFlusher.
+type MockFlusherMockRecorder struct {
+	mock *MockFlusher
+}
+
+// NewMockFlusher creates a new mock instance.
+func NewMockFlusher(ctrl *gomock.Controller) *MockFlusher {
+	mock := &MockFlusher{ctrl: ctrl}
+	mock.recorder = &MockFlusherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlusher) EXPECT() *MockFlusherMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockFlusher) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockFlusherMockRecorder) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockFlusher)(nil).Flush), ctx)
+}
+
+// MockAtomicCache is a mock of AtomicCache interface.
+type MockAtomicCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockAtomicCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockAtomicCacheMockRecorder is the mock recorder for MockAtomicCache.
+type MockAtomicCacheMockRecorder struct {
+	mock *MockAtomicCache
+}
+
+// NewMockAtomicCache creates a new mock instance.
+func NewMockAtomicCache(ctrl *gomock.Controller) *MockAtomicCache {
+	mock := &MockAtomicCache{ctrl: ctrl}
+	mock.recorder = &MockAtomicCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAtomicCache) EXPECT() *MockAtomicCacheMockRecorder {
+	return m.recorder
+}
+
+// CompareAndSwap mocks base method.
+func (m *MockAtomicCache) CompareAndSwap(ctx context.Context, key string, old, new any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSwap", ctx, key, old, new)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSwap indicates an expected call of CompareAndSwap.
+func (mr *MockAtomicCacheMockRecorder) CompareAndSwap(ctx, key, old, new any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockAtomicCache)(nil).CompareAndSwap), ctx, key, old, new)
+}
+
+// Decrement mocks base method.
+func (m *MockAtomicCache) Decrement(ctx context.Context, key string, delta int64, ttlSeconds int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrement", ctx, key, delta, ttlSeconds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrement indicates an expected call of Decrement.
+func (mr *MockAtomicCacheMockRecorder) Decrement(ctx, key, delta, ttlSeconds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrement", reflect.TypeOf((*MockAtomicCache)(nil).Decrement), ctx, key, delta, ttlSeconds)
+}
+
+// Increment mocks base method.
+func (m *MockAtomicCache) Increment(ctx context.Context, key string, delta int64, ttlSeconds int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Increment", ctx, key, delta, ttlSeconds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockAtomicCacheMockRecorder) Increment(ctx, key, delta, ttlSeconds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockAtomicCache)(nil).Increment), ctx, key, delta, ttlSeconds)
+}
+
+// SetNX mocks base method.
+func (m *MockAtomicCache) SetNX(ctx context.Context, key string, value any, ttlSeconds int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", ctx, key, value, ttlSeconds)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockAtomicCacheMockRecorder) SetNX(ctx, key, value, ttlSeconds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockAtomicCache)(nil).SetNX), ctx, key, value, ttlSeconds)
+}
+
+// MockStatsProvider is a mock of StatsProvider interface.
+type MockStatsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsProviderMockRecorder is the mock recorder for MockStatsProvider.
+type MockStatsProviderMockRecorder struct {
+	mock *MockStatsProvider
+}
+
+// NewMockStatsProvider creates a new mock instance.
+func NewMockStatsProvider(ctrl *gomock.Controller) *MockStatsProvider {
+	mock := &MockStatsProvider{ctrl: ctrl}
+	mock.recorder = &MockStatsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsProvider) EXPECT() *MockStatsProviderMockRecorder {
+	return m.recorder
+}
+
+// Stats mocks base method.
+func (m *MockStatsProvider) Stats() cache.Stats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(cache.Stats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockStatsProviderMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockStatsProvider)(nil).Stats))
+}
+
+// MockLocker is a mock of Locker interface.
+type MockLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockerMockRecorder
+	isgomock struct{}
+}
+
+// MockLockerMockRecorder is the mock recorder for MockLocker.
+type MockLockerMockRecorder struct {
+	mock *MockLocker
+}
+
+// NewMockLocker creates a new mock instance.
+func NewMockLocker(ctrl *gomock.Controller) *MockLocker {
+	mock := &MockLocker{ctrl: ctrl}
+	mock.recorder = &MockLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocker) EXPECT() *MockLockerMockRecorder {
+	return m.recorder
+}
+
+// Lock mocks base method.
+func (m *MockLocker) Lock(ctx context.Context, key string, ttl time.Duration) (*cache.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, key, ttl)
+	ret0, _ := ret[0].(*cache.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockLockerMockRecorder) Lock(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockLocker)(nil).Lock), ctx, key, ttl)
+}
+
+// TryLock mocks base method.
+func (m *MockLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (*cache.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, key, ttl)
+	ret0, _ := ret[0].(*cache.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockLockerMockRecorder) TryLock(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockLocker)(nil).TryLock), ctx, key, ttl)
+}
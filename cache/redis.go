@@ -2,37 +2,415 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	// lockPollInterval adalah jeda antar percobaan saat menunggu lock dilepas pihak lain.
+	lockPollInterval = 50 * time.Millisecond
+
+	// scanCount adalah jumlah key per iterasi SCAN/SSCAN saat invalidasi dan flush.
+	scanCount = 500
+
+	// tagKeyPrefix dipakai untuk Set berisi key per tag, di bawah Config.Prefix.
+	tagKeyPrefix = "__tag:"
+)
+
+// setWithTagsScript menyimpan value lalu mendaftarkan key ke setiap tag set.
+// TTL tag set tidak pernah lebih pendek dari key di dalamnya.
+// KEYS[1] = key, KEYS[2..] = tag set; ARGV[1] = value, ARGV[2] = ttl detik (0 = tanpa expiry)
+var setWithTagsScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call("SET", KEYS[1], ARGV[1], "EX", ttl)
+else
+	redis.call("SET", KEYS[1], ARGV[1])
+end
+
+for i = 2, #KEYS do
+	local existed = redis.call("EXISTS", KEYS[i])
+	local current = redis.call("TTL", KEYS[i])
+	redis.call("SADD", KEYS[i], KEYS[1])
+	if ttl <= 0 then
+		redis.call("PERSIST", KEYS[i])
+	elseif existed == 0 or (current >= 0 and current < ttl) then
+		redis.call("EXPIRE", KEYS[i], ttl)
+	end
+end
+return 1
+`)
+
+// incrementScript menjalankan INCRBY dan memasang TTL hanya kalau key baru dibuat.
+// KEYS[1] = key; ARGV[1] = delta, ARGV[2] = ttl detik (0 = tanpa expiry)
+var incrementScript = redis.NewScript(`
+local existed = redis.call("EXISTS", KEYS[1])
+local value = redis.call("INCRBY", KEYS[1], ARGV[1])
+if existed == 0 and tonumber(ARGV[2]) > 0 then
+	redis.call("EXPIRE", KEYS[1], ARGV[2])
+end
+return value
+`)
+
+// compareAndSwapScript mengganti value kalau sama dengan ARGV[1], dengan TTL yang sama.
+// KEYS[1] = key; ARGV[1] = old, ARGV[2] = new
+var compareAndSwapScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+local ttl = redis.call("PTTL", KEYS[1])
+redis.call("SET", KEYS[1], ARGV[2])
+if ttl > 0 then
+	redis.call("PEXPIRE", KEYS[1], ttl)
+end
+return 1
+`)
+
 type RedisCache struct {
 	cfg    *Config
 	client *redis.Client
+
+	flight flightGroup
+	stats  statsCounter
+	locks  Locker
 }
 
 func NewRedisCache(cfg *Config, client *redis.Client) Cache {
-	return &RedisCache{cfg: cfg, client: client}
+	return &RedisCache{cfg: cfg, client: client, locks: NewRedisLocker(cfg, client)}
 }
 
+// Set menyimpan key dengan TTL dalam detik.
+// ttlSeconds <= 0 => tidak ada expiry, sama seperti LocalCache.
 func (r *RedisCache) Set(ctx context.Context, key string, value any, ttlSeconds int) error {
 	key = r.cfg.Prefix + key
-	return r.client.Set(ctx, key, value, time.Duration(ttlSeconds)*time.Second).Err()
+
+	// Pakai konversi yang sama dengan LocalCache supaya hasil Get identik di semua backend
+	stringValue, err := toString(value)
+	if err != nil {
+		return err
+	}
+
+	err = r.client.Set(ctx, key, stringValue, ttl(ttlSeconds)).Err()
+	return r.stats.record(&r.stats.sets, 1, err)
 }
 
 func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
 	key = r.cfg.Prefix + key
-	return r.client.Get(ctx, key).Result()
+
+	value, err := r.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		err = ErrNotFound
+	}
+
+	r.stats.recordGet(err)
+	return value, err
+}
+
+// Stats mengembalikan snapshot statistik RedisCache ini (bukan statistik server Redis).
+// Entries, Bytes, Evictions dan Expirations tidak diisi karena dikelola Redis.
+func (r *RedisCache) Stats() Stats {
+	return r.stats.snapshot()
+}
+
+// getWithTTL membaca value dan sisa TTL dalam satu pipeline.
+// Sisa TTL <= 0 berarti key tidak punya expiry.
+func (r *RedisCache) getWithTTL(ctx context.Context, key string) (string, time.Duration, error) {
+	key = r.cfg.Prefix + key
+
+	var get *redis.StringCmd
+	var pttl *redis.DurationCmd
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		pttl = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		err = ErrNotFound
+	}
+
+	r.stats.recordGet(err)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return get.Val(), pttl.Val(), nil
 }
 
 func (r *RedisCache) Delete(ctx context.Context, key string) error {
 	key = r.cfg.Prefix + key
-	return r.client.Del(ctx, key).Err()
+	err := r.client.Del(ctx, key).Err()
+	return r.stats.record(&r.stats.deletes, 1, err)
 }
 
 func (r *RedisCache) Has(ctx context.Context, key string) (bool, error) {
 	key = r.cfg.Prefix + key
 	count, err := r.client.Exists(ctx, key).Result()
-	return count > 0, err
+	return count > 0, r.stats.fail(err)
+}
+
+// GetMany membaca banyak key dengan satu MGET. Map hasil hanya berisi key yang ditemukan.
+func (r *RedisCache) GetMany(ctx context.Context, keys []string) (map[string]string, error) {
+	result := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	values, err := r.client.MGet(ctx, r.prefixed(keys)...).Result()
+	if err != nil {
+		return nil, r.stats.fail(err)
+	}
+
+	for i, value := range values {
+		// nil berarti key tidak ada
+		if s, ok := value.(string); ok {
+			result[keys[i]] = s
+		}
+	}
+
+	r.stats.hits.Add(uint64(len(result)))
+	r.stats.misses.Add(uint64(len(keys) - len(result)))
+	return result, nil
+}
+
+// SetMany menyimpan banyak key dalam satu pipeline SET.
+func (r *RedisCache) SetMany(ctx context.Context, values map[string]any, ttlSeconds int) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			stringValue, err := toString(value)
+			if err != nil {
+				return err
+			}
+			pipe.Set(ctx, r.cfg.Prefix+key, stringValue, ttl(ttlSeconds))
+		}
+		return nil
+	})
+	return r.stats.record(&r.stats.sets, len(values), err)
+}
+
+// DeleteMany menghapus banyak key dengan satu DEL.
+func (r *RedisCache) DeleteMany(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	err := r.client.Del(ctx, r.prefixed(keys)...).Err()
+	return r.stats.record(&r.stats.deletes, len(keys), err)
+}
+
+// SetWithTags seperti Set, tapi key juga didaftarkan ke tag set di Redis.
+func (r *RedisCache) SetWithTags(ctx context.Context, key string, value any, ttlSeconds int, tags ...string) error {
+	stringValue, err := toString(value)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(tags)+1)
+	keys = append(keys, r.cfg.Prefix+key)
+	for _, tag := range tags {
+		keys = append(keys, r.tagKey(tag))
+	}
+
+	seconds := ttlSeconds
+	if seconds < 0 {
+		seconds = 0
+	}
+
+	err = setWithTagsScript.Run(ctx, r.client, keys, stringValue, seconds).Err()
+	return r.stats.record(&r.stats.sets, 1, err)
+}
+
+// InvalidateTags menghapus semua key yang terdaftar di tag, lalu tag set-nya sendiri.
+// Anggota tag set dibaca bertahap dengan SSCAN.
+func (r *RedisCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	for _, tag := range tags {
+		tagKey := r.tagKey(tag)
+
+		var cursor uint64
+		for {
+			keys, next, err := r.client.SScan(ctx, tagKey, cursor, "", scanCount).Result()
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				if err := r.client.Del(ctx, keys...).Err(); err != nil {
+					return err
+				}
+			}
+
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+
+		if err := r.client.Del(ctx, tagKey).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush menghapus semua key di bawah Config.Prefix (termasuk tag set) memakai SCAN.
+// Mengembalikan ErrFlushWithoutPrefix kalau prefix kosong.
+func (r *RedisCache) Flush(ctx context.Context) error {
+	if r.cfg.Prefix == "" {
+		return ErrFlushWithoutPrefix
+	}
+
+	match := escapePattern(r.cfg.Prefix) + "*"
+
+	var cursor uint64
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, match, scanCount).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := r.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// Increment menjalankan INCRBY secara atomik. TTL hanya dipasang saat key baru dibuat.
+func (r *RedisCache) Increment(ctx context.Context, key string, delta int64, ttlSeconds int) (int64, error) {
+	seconds := ttlSeconds
+	if seconds < 0 {
+		seconds = 0
+	}
+
+	value, err := incrementScript.Run(ctx, r.client, []string{r.cfg.Prefix + key}, delta, seconds).Int64()
+	if err != nil && strings.Contains(err.Error(), "not an integer") {
+		return 0, ErrNotInteger
+	}
+	return value, err
+}
+
+func (r *RedisCache) Decrement(ctx context.Context, key string, delta int64, ttlSeconds int) (int64, error) {
+	return r.Increment(ctx, key, -delta, ttlSeconds)
+}
+
+// SetNX menyimpan value hanya kalau key belum ada.
+func (r *RedisCache) SetNX(ctx context.Context, key string, value any, ttlSeconds int) (bool, error) {
+	stringValue, err := toString(value)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetNX(ctx, r.cfg.Prefix+key, stringValue, ttl(ttlSeconds)).Result()
+}
+
+// CompareAndSwap mengganti value kalau value sekarang sama dengan old, memakai Lua supaya atomik.
+func (r *RedisCache) CompareAndSwap(ctx context.Context, key string, old, new any) (bool, error) {
+	oldValue, err := toString(old)
+	if err != nil {
+		return false, err
+	}
+	newValue, err := toString(new)
+	if err != nil {
+		return false, err
+	}
+
+	swapped, err := compareAndSwapScript.Run(ctx, r.client, []string{r.cfg.Prefix + key}, oldValue, newValue).Int()
+	return swapped == 1, err
+}
+
+// Remember mengambil key, atau menjalankan loader sekali per proses kalau key belum ada.
+// Dengan WithLock, loader juga hanya dijalankan oleh satu replica; replica lain
+// menunggu sampai nilainya muncul di Redis.
+func (r *RedisCache) Remember(ctx context.Context, key string, ttlSeconds int, loader Loader, opts ...RememberOption) (string, error) {
+	value, err := r.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+	if !isNotFound(err) {
+		return "", err
+	}
+
+	o := newRememberOptions(opts)
+
+	return r.flight.do(ctx, key, func() (string, error) {
+		if o.lockTTL <= 0 {
+			return loadAndSet(ctx, r, key, ttlSeconds, loader)
+		}
+		return r.loadWithLock(ctx, key, ttlSeconds, loader, o.lockTTL)
+	})
+}
+
+func (r *RedisCache) loadWithLock(ctx context.Context, key string, ttlSeconds int, loader Loader, lockTTL time.Duration) (string, error) {
+	lockKey := key + ":remember"
+
+	for {
+		lock, err := r.locks.TryLock(ctx, lockKey, lockTTL)
+		if err == nil {
+			defer lock.Release(context.WithoutCancel(ctx))
+			return loadAndSet(ctx, r, key, ttlSeconds, loader)
+		}
+		if !errors.Is(err, ErrLockNotAcquired) {
+			return "", err
+		}
+
+		// Replica lain sedang load, tunggu hasilnya muncul.
+		// Kalau pemegang lock mati, lock expired dan SetNX berikutnya berhasil.
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+
+		value, err := r.Get(ctx, key)
+		if err == nil {
+			return value, nil
+		}
+		if !isNotFound(err) {
+			return "", err
+		}
+	}
+}
+
+// Helper: key untuk tag set
+func (r *RedisCache) tagKey(tag string) string {
+	return r.cfg.Prefix + tagKeyPrefix + tag
+}
+
+// Helper: escape karakter glob supaya prefix dicocokkan apa adanya oleh SCAN MATCH
+func escapePattern(s string) string {
+	var b strings.Builder
+	for _, ch := range s {
+		switch ch {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+// Helper: tambahkan prefix ke semua key
+func (r *RedisCache) prefixed(keys []string) []string {
+	out := make([]string, len(keys))
+	for i, key := range keys {
+		out[i] = r.cfg.Prefix + key
+	}
+	return out
+}
+
+// Helper: konversi TTL detik ke expiration go-redis.
+// Negatif harus jadi 0, karena -1 di go-redis berarti KEEPTTL.
+func ttl(ttlSeconds int) time.Duration {
+	if ttlSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(ttlSeconds) * time.Second
 }
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Loader menghasilkan nilai baru ketika key belum ada di cache.
+type Loader func(ctx context.Context) (string, error)
+
+// RememberOption mengatur perilaku Remember.
+type RememberOption func(*rememberOptions)
+
+type rememberOptions struct {
+	lockTTL time.Duration
+}
+
+// WithLock mengaktifkan distributed lock selama loader berjalan, supaya hanya satu
+// replica yang menghitung ulang key yang sama. ttl adalah umur maksimal lock;
+// kalau pemegang lock mati, replica lain bisa mengambil alih setelah ttl habis.
+// Hanya berpengaruh pada RedisCache, LocalCache cukup dengan dedup in-process.
+func WithLock(ttl time.Duration) RememberOption {
+	return func(o *rememberOptions) {
+		o.lockTTL = ttl
+	}
+}
+
+func newRememberOptions(opts []RememberOption) rememberOptions {
+	var o rememberOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Remember mengambil key dari cache. Kalau tidak ada, loader dipanggil lalu hasilnya
+// disimpan dengan ttlSeconds. Kalau c mengimplementasikan Rememberer (LocalCache dan
+// RedisCache), pemanggilan loader untuk key yang sama di-dedup antar goroutine.
+func Remember(ctx context.Context, c Cache, key string, ttlSeconds int, loader Loader, opts ...RememberOption) (string, error) {
+	if r, ok := c.(Rememberer); ok {
+		return r.Remember(ctx, key, ttlSeconds, loader, opts...)
+	}
+	return loadAndSet(ctx, c, key, ttlSeconds, loader)
+}
+
+// loadAndSet cek cache sekali lagi (bisa saja sudah diisi pemanggil lain),
+// lalu menjalankan loader dan menyimpan hasilnya.
+func loadAndSet(ctx context.Context, c Cache, key string, ttlSeconds int, loader Loader) (string, error) {
+	value, err := c.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+	if !isNotFound(err) {
+		return "", err
+	}
+
+	value, err = loader(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.Set(ctx, key, value, ttlSeconds); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
+
+// Helper: cek apakah error berarti key tidak ada
+func isNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// =============== SINGLEFLIGHT ===============
+
+type flightCall struct {
+	done  chan struct{}
+	value string
+	err   error
+}
+
+// flightGroup memastikan hanya satu fn yang berjalan per key dalam satu proses.
+// Pemanggil lain menunggu dan menerima hasil yang sama.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (string, error)) (string, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	// Kalau fn panic, panic diteruskan ke pemanggil ini; yang menunggu menerima ErrLoaderPanic,
+	// bukan value kosong tanpa error
+	completed := false
+	defer func() {
+		if !completed {
+			call.value, call.err = "", fmt.Errorf("%w: %s", ErrLoaderPanic, key)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = fn()
+	completed = true
+	return call.value, call.err
+}
@@ -0,0 +1,182 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/cache"
+)
+
+func TestRemember_DedupConcurrentLoads(t *testing.T) {
+	_, client := newMiniredis(t)
+
+	backends := map[string]cache.Cache{
+		"local": cache.NewLocalCache(&cache.Config{}),
+		"redis": cache.NewRedisCache(&cache.Config{Prefix: "remember:"}, client),
+	}
+
+	for name, c := range backends {
+		t.Run(name, func(t *testing.T) {
+			var calls atomic.Int32
+			loader := func(ctx context.Context) (string, error) {
+				calls.Add(1)
+				time.Sleep(50 * time.Millisecond)
+				return "computed", nil
+			}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					value, err := cache.Remember(context.Background(), c, "report", 60, loader, cache.WithLock(time.Second))
+					if err != nil || value != "computed" {
+						t.Errorf("Remember = %q, %v", value, err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := calls.Load(); got != 1 {
+				t.Fatalf("loader called %d times, want 1", got)
+			}
+		})
+	}
+}
+
+func TestRemember_RedisLockContention(t *testing.T) {
+	_, client := newMiniredis(t)
+	cfg := &cache.Config{Prefix: "remember:"}
+	c := cache.NewRedisCache(cfg, client)
+	ctx := context.Background()
+
+	// Replica lain sedang menjalankan loader untuk key yang sama
+	other, err := cache.NewRedisLocker(cfg, client).TryLock(ctx, "report:remember", time.Second)
+	if err != nil {
+		t.Fatalf("TryLock: %v", err)
+	}
+
+	var calls atomic.Int32
+	result := make(chan string, 1)
+	go func() {
+		value, err := cache.Remember(ctx, c, "report", 60, func(ctx context.Context) (string, error) {
+			calls.Add(1)
+			return "local", nil
+		}, cache.WithLock(time.Second))
+		if err != nil {
+			t.Errorf("Remember: %v", err)
+		}
+		result <- value
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case value := <-result:
+		t.Fatalf("Remember returned %q while lock was held", value)
+	default:
+	}
+
+	// Replica lain selesai: nilai disimpan lalu lock dilepas
+	if err := c.Set(ctx, "report", "remote", 60); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := other.Release(ctx); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	select {
+	case value := <-result:
+		if value != "remote" {
+			t.Fatalf("Remember = %q, want value from the lock holder", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Remember did not return after lock was released")
+	}
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("loader called %d times, want 0", got)
+	}
+}
+
+func TestRemember_RedisLockLoaderError(t *testing.T) {
+	_, client := newMiniredis(t)
+	cfg := &cache.Config{Prefix: "remember:"}
+	c := cache.NewRedisCache(cfg, client)
+	ctx := context.Background()
+
+	errLoad := errors.New("upstream down")
+	_, err := cache.Remember(ctx, c, "report", 60, func(ctx context.Context) (string, error) {
+		return "", errLoad
+	}, cache.WithLock(time.Second))
+	if !errors.Is(err, errLoad) {
+		t.Fatalf("Remember error = %v, want %v", err, errLoad)
+	}
+
+	if ok, err := c.Has(ctx, "report"); err != nil || ok {
+		t.Fatalf("Has after loader error = %v, %v; error must not be cached", ok, err)
+	}
+
+	// Lock dilepas walaupun loader gagal
+	lock, err := cache.NewRedisLocker(cfg, client).TryLock(ctx, "report:remember", time.Second)
+	if err != nil {
+		t.Fatalf("lock still held after loader error: %v", err)
+	}
+	_ = lock.Release(ctx)
+
+	value, err := cache.Remember(ctx, c, "report", 60, func(ctx context.Context) (string, error) {
+		return "computed", nil
+	}, cache.WithLock(time.Second))
+	if err != nil || value != "computed" {
+		t.Fatalf("Remember after error = %q, %v", value, err)
+	}
+}
+
+func TestRemember_LoaderPanicReleasesWaiters(t *testing.T) {
+	c := cache.NewLocalCache(&cache.Config{})
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	loader := func(ctx context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+			panic("report generator crashed")
+		}
+		return "computed", nil
+	}
+
+	panicked := make(chan any)
+	go func() {
+		defer func() { panicked <- recover() }()
+		_, _ = cache.Remember(ctx, c, "report", 60, loader)
+	}()
+	<-started
+
+	waiter := make(chan error)
+	go func() {
+		value, err := cache.Remember(ctx, c, "report", 60, loader)
+		if err == nil {
+			err = errors.New("no error, value " + value)
+		}
+		waiter <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if r := <-panicked; r == nil {
+		t.Fatal("expected the loader panic to reach the first caller")
+	}
+	if err := <-waiter; !errors.Is(err, cache.ErrLoaderPanic) {
+		t.Fatalf("waiter error = %v, want ErrLoaderPanic", err)
+	}
+
+	// Panic tidak di-cache: pemanggil berikutnya menjalankan loader lagi
+	if value, err := cache.Remember(ctx, c, "report", 60, loader); err != nil || value != "computed" {
+		t.Fatalf("Remember after panic = %q, %v", value, err)
+	}
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Stats adalah snapshot statistik sebuah cache.
+type Stats struct {
+	Hits    uint64
+	Misses  uint64
+	Sets    uint64
+	Deletes uint64
+	Errors  uint64
+
+	// Evictions hanya menghitung item yang dibuang karena kapasitas penuh (MaxEntries / MaxBytes),
+	// Expirations menghitung item yang dibuang karena TTL habis. Keduanya hanya diisi LocalCache.
+	Evictions   uint64
+	Expirations uint64
+
+	// Entries dan Bytes hanya diisi LocalCache
+	Entries int
+	Bytes   int64
+}
+
+// HitRatio mengembalikan Hits / (Hits + Misses), atau 0 kalau belum ada Get.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type statsCounter struct {
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	sets        atomic.Uint64
+	deletes     atomic.Uint64
+	evictions   atomic.Uint64
+	expirations atomic.Uint64
+	errors      atomic.Uint64
+}
+
+func (s *statsCounter) snapshot() Stats {
+	return Stats{
+		Hits:    s.hits.Load(),
+		Misses:  s.misses.Load(),
+		Sets:    s.sets.Load(),
+		Deletes: s.deletes.Load(),
+		Errors:  s.errors.Load(),
+
+		Evictions:   s.evictions.Load(),
+		Expirations: s.expirations.Load(),
+	}
+}
+
+// recordGet mencatat hasil satu Get: nil = hit, ErrNotFound = miss, selain itu error.
+func (s *statsCounter) recordGet(err error) {
+	switch {
+	case err == nil:
+		s.hits.Add(1)
+	case errors.Is(err, ErrNotFound):
+		s.misses.Add(1)
+	default:
+		s.errors.Add(1)
+	}
+}
+
+// record menambah counter kalau err nil, atau counter error kalau tidak. err dikembalikan apa adanya.
+func (s *statsCounter) record(counter *atomic.Uint64, n int, err error) error {
+	if err != nil {
+		s.errors.Add(1)
+		return err
+	}
+	counter.Add(uint64(n))
+	return nil
+}
+
+// fail menambah counter error kalau err tidak nil. err dikembalikan apa adanya.
+func (s *statsCounter) fail(err error) error {
+	if err != nil {
+		s.errors.Add(1)
+	}
+	return err
+}
@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TieredConfig mengatur TieredCache.
+type TieredConfig struct {
+	// NearTTLSeconds adalah TTL salinan di near cache. Sebaiknya pendek (beberapa detik)
+	// karena itu batas maksimal data basi kalau pesan invalidasi hilang. Default 5.
+	NearTTLSeconds int
+
+	// Channel adalah channel pub/sub untuk invalidasi antar instance.
+	// Default "<prefix far cache>cache:invalidate".
+	Channel string
+}
+
+// TieredCache menaruh LocalCache kecil (near) di depan RedisCache (far).
+// Get membaca near dulu, kalau miss baru ke far lalu menyalin hasilnya ke near.
+// Set dan Delete menulis ke far lalu mem-publish key ke Channel, supaya semua
+// instance membuang salinan near-nya. InvalidateTags dan Flush mengosongkan near
+// di semua instance, karena near tidak tahu key mana yang punya tag tertentu.
+type TieredCache struct {
+	near     Cache
+	far      *RedisCache
+	gens     [invalidationShards]atomic.Uint64 // versi invalidasi per kelompok key, lihat Get
+	flushGen atomic.Uint64                     // naik setiap near dikosongkan
+	nearTTL  int
+	channel  string
+	id       string // untuk mengabaikan pesan dari diri sendiri
+
+	pubsub    *redis.PubSub
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Compile-time check
+var (
+	_ Cache    = (*TieredCache)(nil)
+	_ TagCache = (*TieredCache)(nil)
+	_ Flusher  = (*TieredCache)(nil)
+)
+
+// NewTieredCache membuat TieredCache dan mulai subscribe ke channel invalidasi.
+// near biasanya NewLocalCache dengan MaxEntries kecil dan harus mengimplementasikan Flusher;
+// far harus dibuat dengan NewRedisCache. cfg boleh nil. Panggil Close saat shutdown untuk berhenti subscribe.
+func NewTieredCache(cfg *TieredConfig, near Cache, far Cache) (*TieredCache, error) {
+	redisFar, ok := far.(*RedisCache)
+	if !ok {
+		return nil, ErrFarNotRedis
+	}
+	if _, ok := near.(Flusher); !ok {
+		return nil, ErrNearNotFlusher
+	}
+
+	if cfg == nil {
+		cfg = &TieredConfig{}
+	}
+	cfg = &TieredConfig{NearTTLSeconds: cfg.NearTTLSeconds, Channel: cfg.Channel}
+	if cfg.NearTTLSeconds <= 0 {
+		cfg.NearTTLSeconds = 5
+	}
+	if cfg.Channel == "" {
+		cfg.Channel = redisFar.cfg.Prefix + "cache:invalidate"
+	}
+
+	id, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// Tunggu konfirmasi subscribe supaya tidak ada pesan yang terlewat setelah constructor selesai
+	ctx := context.Background()
+	pubsub := redisFar.client.Subscribe(ctx, cfg.Channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	t := &TieredCache{
+		near:    near,
+		far:     redisFar,
+		nearTTL: cfg.NearTTLSeconds,
+		channel: cfg.Channel,
+		id:      id,
+		pubsub:  pubsub,
+		done:    make(chan struct{}),
+	}
+
+	go t.listen()
+
+	return t, nil
+}
+
+func (t *TieredCache) Set(ctx context.Context, key string, value any, ttlSeconds int) error {
+	if err := t.far.Set(ctx, key, value, ttlSeconds); err != nil {
+		return err
+	}
+
+	// Hapus salinan lokal, nilai baru akan diambil dari far saat Get berikutnya
+	t.invalidateNear(ctx, key)
+	return t.publish(ctx, key)
+}
+
+func (t *TieredCache) Get(ctx context.Context, key string) (string, error) {
+	if value, err := t.near.Get(ctx, key); err == nil {
+		return value, nil
+	}
+
+	// Invalidasi key ini yang datang setelah titik ini bisa jadi lebih baru dari nilai yang dibaca dari far
+	gen := t.generation(key)
+
+	value, remaining, err := t.far.getWithTTL(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	// Salinan near tidak boleh hidup lebih lama dari key aslinya
+	nearTTL := t.nearTTL
+	if remaining > 0 {
+		seconds := int((remaining + time.Second - 1) / time.Second)
+		nearTTL = min(nearTTL, seconds)
+	}
+
+	if t.generation(key) != gen {
+		return value, nil
+	}
+	_ = t.near.Set(ctx, key, value, nearTTL)
+
+	// Invalidasi yang terjadi bersamaan dengan Set di atas: buang lagi salinan yang mungkin basi
+	if t.generation(key) != gen {
+		_ = t.near.Delete(ctx, key)
+	}
+	return value, nil
+}
+
+func (t *TieredCache) Delete(ctx context.Context, key string) error {
+	if err := t.far.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	t.invalidateNear(ctx, key)
+	return t.publish(ctx, key)
+}
+
+func (t *TieredCache) SetWithTags(ctx context.Context, key string, value any, ttlSeconds int, tags ...string) error {
+	if err := t.far.SetWithTags(ctx, key, value, ttlSeconds, tags...); err != nil {
+		return err
+	}
+
+	t.invalidateNear(ctx, key)
+	return t.publish(ctx, key)
+}
+
+func (t *TieredCache) InvalidateTags(ctx context.Context, tags ...string) error {
+	if err := t.far.InvalidateTags(ctx, tags...); err != nil {
+		return err
+	}
+
+	t.flushNear(ctx)
+	return t.publish(ctx, flushMessage)
+}
+
+// Flush menghapus semua key di far (lihat RedisCache.Flush) dan mengosongkan near di semua instance.
+func (t *TieredCache) Flush(ctx context.Context) error {
+	if err := t.far.Flush(ctx); err != nil {
+		return err
+	}
+
+	t.flushNear(ctx)
+	return t.publish(ctx, flushMessage)
+}
+
+func (t *TieredCache) Has(ctx context.Context, key string) (bool, error) {
+	if ok, err := t.near.Has(ctx, key); err == nil && ok {
+		return true, nil
+	}
+	return t.far.Has(ctx, key)
+}
+
+// Close berhenti subscribe. Near dan far cache tidak ditutup.
+func (t *TieredCache) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		err = t.pubsub.Close()
+		<-t.done
+	})
+	return err
+}
+
+// =============== INVALIDATION ===============
+
+// flushMessage adalah key kosong, artinya seluruh near dikosongkan
+const flushMessage = ""
+
+// invalidationShards adalah jumlah counter versi. Key dikelompokkan dengan hash supaya memori
+// tetap kecil; invalidasi key lain di kelompok yang sama hanya membatalkan satu write-back.
+const invalidationShards = 256
+
+func (t *TieredCache) shard(key string) *atomic.Uint64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return &t.gens[h.Sum32()%invalidationShards]
+}
+
+// generation berubah setiap key (atau kelompoknya) diinvalidasi atau near dikosongkan.
+// Kedua counter hanya naik, jadi jumlahnya berubah kalau salah satunya berubah.
+func (t *TieredCache) generation(key string) uint64 {
+	return t.shard(key).Load() + t.flushGen.Load()
+}
+
+// invalidateNear menaikkan versi key dulu baru menghapus salinan near, supaya Get yang sedang
+// berjalan pasti melihat perubahan versi sebelum atau sesudah salinannya ditulis
+func (t *TieredCache) invalidateNear(ctx context.Context, key string) {
+	t.shard(key).Add(1)
+	_ = t.near.Delete(ctx, key)
+}
+
+func (t *TieredCache) flushNear(ctx context.Context) {
+	t.flushGen.Add(1)
+	_ = t.near.(Flusher).Flush(ctx)
+}
+
+// Format pesan: "<id instance pengirim>|<key>"
+func (t *TieredCache) publish(ctx context.Context, key string) error {
+	return t.far.client.Publish(ctx, t.channel, t.id+"|"+key).Err()
+}
+
+func (t *TieredCache) listen() {
+	defer close(t.done)
+
+	for msg := range t.pubsub.Channel() {
+		sender, key, ok := strings.Cut(msg.Payload, "|")
+		if !ok || sender == t.id {
+			continue
+		}
+		if key == flushMessage {
+			t.flushNear(context.Background())
+			continue
+		}
+		t.invalidateNear(context.Background(), key)
+	}
+}
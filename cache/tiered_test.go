@@ -0,0 +1,207 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/cache"
+)
+
+func TestTieredCache_CrossInstanceInvalidation(t *testing.T) {
+	_, client := newMiniredis(t)
+	cfg := &cache.Config{Prefix: "tiered:"}
+
+	newInstance := func() (*cache.TieredCache, cache.Cache) {
+		near := cache.NewLocalCache(&cache.Config{MaxEntries: 100})
+		tc, err := cache.NewTieredCache(&cache.TieredConfig{NearTTLSeconds: 60}, near, cache.NewRedisCache(cfg, client))
+		if err != nil {
+			t.Fatalf("NewTieredCache: %v", err)
+		}
+		t.Cleanup(func() { _ = tc.Close() })
+		return tc, near
+	}
+
+	podA, _ := newInstance()
+	podB, nearB := newInstance()
+	ctx := context.Background()
+
+	// Tulis langsung ke far: pesan invalidasi dari podA.Set bisa sampai di pod B saat Get
+	// di bawah sedang berjalan dan (dengan benar) membatalkan write-back
+	if err := cache.NewRedisCache(cfg, client).Set(ctx, "user:1", "John", 60); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// Pod B membaca dan menyimpan salinan near
+	if v, err := podB.Get(ctx, "user:1"); err != nil || v != "John" {
+		t.Fatalf("Get = %q, %v", v, err)
+	}
+	if ok, _ := nearB.Has(ctx, "user:1"); !ok {
+		t.Fatal("expected near copy on pod B")
+	}
+
+	if err := podA.Delete(ctx, "user:1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if ok, _ := nearB.Has(ctx, "user:1"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("near copy on pod B was not invalidated")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTieredCache_RequiresRedisFar(t *testing.T) {
+	_, err := cache.NewTieredCache(nil, cache.NewLocalCache(&cache.Config{}), cache.NewLocalCache(&cache.Config{}))
+	if err != cache.ErrFarNotRedis {
+		t.Fatalf("error = %v, want ErrFarNotRedis", err)
+	}
+}
+
+// racingNear menjalankan onSet tepat sebelum Set, untuk mensimulasikan invalidasi
+// yang datang di antara baca far dan tulis near
+type racingNear struct {
+	cache.Cache
+	onSet func()
+}
+
+func (n *racingNear) Flush(ctx context.Context) error {
+	return n.Cache.(cache.Flusher).Flush(ctx)
+}
+
+func (n *racingNear) Set(ctx context.Context, key string, value any, ttlSeconds int) error {
+	if n.onSet != nil {
+		onSet := n.onSet
+		n.onSet = nil
+		onSet()
+	}
+	return n.Cache.Set(ctx, key, value, ttlSeconds)
+}
+
+func TestTieredCache_StaleWriteBack(t *testing.T) {
+	_, client := newMiniredis(t)
+	near := &racingNear{Cache: cache.NewLocalCache(&cache.Config{})}
+	tc, err := cache.NewTieredCache(&cache.TieredConfig{NearTTLSeconds: 60}, near, cache.NewRedisCache(&cache.Config{Prefix: "tiered:"}, client))
+	if err != nil {
+		t.Fatalf("NewTieredCache: %v", err)
+	}
+	t.Cleanup(func() { _ = tc.Close() })
+	ctx := context.Background()
+
+	_ = tc.Set(ctx, "user:1", "old", 60)
+	near.onSet = func() {
+		if err := tc.Delete(ctx, "user:1"); err != nil {
+			t.Errorf("Delete: %v", err)
+		}
+	}
+
+	if v, err := tc.Get(ctx, "user:1"); err != nil || v != "old" {
+		t.Fatalf("Get = %q, %v", v, err)
+	}
+	if ok, _ := near.Has(ctx, "user:1"); ok {
+		t.Fatal("stale value written back to near after concurrent Delete")
+	}
+	if _, err := tc.Get(ctx, "user:1"); !errors.Is(err, cache.ErrNotFound) {
+		t.Fatalf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTieredCache_UnrelatedInvalidationKeepsWriteBack(t *testing.T) {
+	_, client := newMiniredis(t)
+	near := &racingNear{Cache: cache.NewLocalCache(&cache.Config{})}
+	tc, err := cache.NewTieredCache(&cache.TieredConfig{NearTTLSeconds: 60}, near, cache.NewRedisCache(&cache.Config{Prefix: "tiered:"}, client))
+	if err != nil {
+		t.Fatalf("NewTieredCache: %v", err)
+	}
+	t.Cleanup(func() { _ = tc.Close() })
+	ctx := context.Background()
+
+	_ = tc.Set(ctx, "user:1", "John", 60)
+	near.onSet = func() {
+		if err := tc.Delete(ctx, "user:2"); err != nil {
+			t.Errorf("Delete: %v", err)
+		}
+	}
+
+	if v, err := tc.Get(ctx, "user:1"); err != nil || v != "John" {
+		t.Fatalf("Get = %q, %v", v, err)
+	}
+	if ok, _ := near.Has(ctx, "user:1"); !ok {
+		t.Fatal("invalidation of another key prevented write-back to near")
+	}
+}
+
+func TestTieredCache_TagsAndFlushInvalidateNear(t *testing.T) {
+	_, client := newMiniredis(t)
+	cfg := &cache.Config{Prefix: "tiered:"}
+
+	newInstance := func() (*cache.TieredCache, cache.Cache) {
+		near := cache.NewLocalCache(&cache.Config{})
+		tc, err := cache.NewTieredCache(&cache.TieredConfig{NearTTLSeconds: 60}, near, cache.NewRedisCache(cfg, client))
+		if err != nil {
+			t.Fatalf("NewTieredCache: %v", err)
+		}
+		t.Cleanup(func() { _ = tc.Close() })
+		return tc, near
+	}
+
+	podA, nearA := newInstance()
+	podB, nearB := newInstance()
+	ctx := context.Background()
+
+	waitEmpty := func(near cache.Cache, key string) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for {
+			if ok, _ := near.Has(ctx, key); !ok {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("near copy of %q was not invalidated", key)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if err := podA.SetWithTags(ctx, "user:1", "John", 60, "users"); err != nil {
+		t.Fatalf("SetWithTags: %v", err)
+	}
+	for _, pod := range []*cache.TieredCache{podA, podB} {
+		if v, err := pod.Get(ctx, "user:1"); err != nil || v != "John" {
+			t.Fatalf("Get = %q, %v", v, err)
+		}
+	}
+
+	if err := podA.InvalidateTags(ctx, "users"); err != nil {
+		t.Fatalf("InvalidateTags: %v", err)
+	}
+	waitEmpty(nearA, "user:1")
+	waitEmpty(nearB, "user:1")
+	if _, err := podB.Get(ctx, "user:1"); !errors.Is(err, cache.ErrNotFound) {
+		t.Fatalf("Get after InvalidateTags error = %v, want ErrNotFound", err)
+	}
+
+	_ = podA.Set(ctx, "user:2", "Jane", 60)
+	if _, err := podB.Get(ctx, "user:2"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := podA.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	waitEmpty(nearB, "user:2")
+}
+
+func TestTieredCache_RequiresFlushableNear(t *testing.T) {
+	_, client := newMiniredis(t)
+	near := struct{ cache.Cache }{cache.NewLocalCache(&cache.Config{})}
+	_, err := cache.NewTieredCache(nil, near, cache.NewRedisCache(&cache.Config{}, client))
+	if !errors.Is(err, cache.ErrNearNotFlusher) {
+		t.Fatalf("error = %v, want ErrNearNotFlusher", err)
+	}
+}
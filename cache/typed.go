@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+)
+
+// TypedCache membungkus Cache supaya bisa menyimpan dan membaca nilai bertipe T.
+// Nilai di-encode dengan Codec sebelum Set dan di-decode setelah Get.
+type TypedCache[T any] struct {
+	cache Cache
+	codec Codec
+}
+
+// NewTypedCache membuat TypedCache di atas cache yang sudah ada.
+// Kalau codec nil, JSONCodec yang dipakai.
+func NewTypedCache[T any](c Cache, codec Codec) *TypedCache[T] {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	return &TypedCache[T]{cache: c, codec: codec}
+}
+
+// Set meng-encode value lalu menyimpannya dengan TTL dalam detik.
+func (t *TypedCache[T]) Set(ctx context.Context, key string, value T, ttlSeconds int) error {
+	data, err := t.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%w (key %q): %w", ErrEncode, key, err)
+	}
+
+	return t.cache.Set(ctx, key, string(data), ttlSeconds)
+}
+
+// Get membaca key lalu men-decode hasilnya ke T.
+// Mengembalikan error dari cache apa adanya (misal ErrNotFound), atau ErrDecode kalau data tidak valid.
+func (t *TypedCache[T]) Get(ctx context.Context, key string) (T, error) {
+	var value T
+
+	raw, err := t.cache.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+
+	if err := t.codec.Unmarshal([]byte(raw), &value); err != nil {
+		var zero T
+		return zero, fmt.Errorf("%w (key %q): %w", ErrDecode, key, err)
+	}
+
+	return value, nil
+}
+
+func (t *TypedCache[T]) Delete(ctx context.Context, key string) error {
+	return t.cache.Delete(ctx, key)
+}
+
+func (t *TypedCache[T]) Has(ctx context.Context, key string) (bool, error) {
+	return t.cache.Has(ctx, key)
+}
+
+// Cache mengembalikan Cache yang dibungkus.
+func (t *TypedCache[T]) Cache() Cache {
+	return t.cache
+}
+
+// Remember seperti Remember pada package ini, tapi loader mengembalikan T.
+func (t *TypedCache[T]) Remember(ctx context.Context, key string, ttlSeconds int, loader func(ctx context.Context) (T, error), opts ...RememberOption) (T, error) {
+	var zero T
+
+	raw, err := Remember(ctx, t.cache, key, ttlSeconds, func(ctx context.Context) (string, error) {
+		value, err := loader(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		data, err := t.codec.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("%w (key %q): %w", ErrEncode, key, err)
+		}
+		return string(data), nil
+	}, opts...)
+	if err != nil {
+		return zero, err
+	}
+
+	var value T
+	if err := t.codec.Unmarshal([]byte(raw), &value); err != nil {
+		return zero, fmt.Errorf("%w (key %q): %w", ErrDecode, key, err)
+	}
+
+	return value, nil
+}
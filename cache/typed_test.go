@@ -0,0 +1,77 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fatkulnurk/foundation/cache"
+)
+
+type typedUser struct {
+	ID   int
+	Name string
+}
+
+func TestTypedCache_RoundTrip(t *testing.T) {
+	codecs := map[string]cache.Codec{
+		"json":    cache.JSONCodec{},
+		"gob":     cache.GobCodec{},
+		"msgpack": cache.MsgpackCodec{},
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			c := cache.NewLocalCache(&cache.Config{})
+			users := cache.NewTypedCache[typedUser](c, codec)
+			ctx := context.Background()
+
+			if err := users.Set(ctx, "user:1", typedUser{ID: 1, Name: "John"}, 60); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+
+			got, err := users.Get(ctx, "user:1")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got != (typedUser{ID: 1, Name: "John"}) {
+				t.Fatalf("Get = %+v", got)
+			}
+
+			_ = c.Set(ctx, "user:2", "not encoded", 60)
+			if _, err := users.Get(ctx, "user:2"); !errors.Is(err, cache.ErrDecode) {
+				t.Fatalf("Get invalid data error = %v, want ErrDecode", err)
+			}
+		})
+	}
+}
+
+func TestTypedCache_NotFoundAndRemember(t *testing.T) {
+	c := cache.NewLocalCache(&cache.Config{})
+	users := cache.NewTypedCache[typedUser](c, nil)
+	ctx := context.Background()
+
+	if _, err := users.Get(ctx, "user:1"); !errors.Is(err, cache.ErrNotFound) {
+		t.Fatalf("Get missing error = %v, want ErrNotFound", err)
+	}
+
+	calls := 0
+	loader := func(ctx context.Context) (typedUser, error) {
+		calls++
+		return typedUser{ID: 1, Name: "John"}, nil
+	}
+	for i := 0; i < 2; i++ {
+		got, err := users.Remember(ctx, "user:1", 60, loader)
+		if err != nil || got.Name != "John" {
+			t.Fatalf("Remember = %+v, %v", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("loader called %d times, want 1", calls)
+	}
+
+	// Default codec JSON, jadi data mentahnya bisa dibaca service lain
+	if raw, _ := c.Get(ctx, "user:1"); raw != `{"ID":1,"Name":"John"}` {
+		t.Fatalf("raw value = %q", raw)
+	}
+}
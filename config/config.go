@@ -0,0 +1,207 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/fatkulnurk/foundation/support"
+	"github.com/fatkulnurk/foundation/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidTarget dikembalikan Load saat dst bukan pointer ke struct
+var ErrInvalidTarget = errors.New("config: target must be a non-nil pointer to a struct")
+
+// FieldError menjelaskan value yang tidak bisa di-parse ke tipe field-nya
+type FieldError struct {
+	Field  string // path field, misalnya Database.Port
+	Source string // asal value, misalnya env DB_PORT atau default
+	Value  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("config: %s: invalid value %q from %s: %v", e.Field, e.Value, e.Source, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+type options struct {
+	prefix   string
+	files    []string
+	envFiles []string
+	lookup   func(key string) (string, bool, error)
+}
+
+type Option func(*options)
+
+// WithPrefix menambahkan prefix ke semua nama env, misalnya "BILLING_"
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix = prefix
+	}
+}
+
+// WithFile menambahkan file YAML (.yaml, .yml) atau JSON (.json). File berikutnya menimpa file sebelumnya.
+// File yang tidak ada dianggap error.
+func WithFile(paths ...string) Option {
+	return func(o *options) {
+		o.files = append(o.files, paths...)
+	}
+}
+
+// WithEnvFile menambahkan file .env. File berikutnya menimpa file sebelumnya,
+// dan environment process selalu menang. File yang tidak ada dilewati.
+func WithEnvFile(paths ...string) Option {
+	return func(o *options) {
+		o.envFiles = append(o.envFiles, paths...)
+	}
+}
+
+// WithLookup mengganti sumber environment (default support.LookupEnv), berguna untuk test
+func WithLookup(lookup func(key string) (string, bool)) Option {
+	return func(o *options) {
+		o.lookup = func(key string) (string, bool, error) {
+			value, ok := lookup(key)
+			return value, ok, nil
+		}
+	}
+}
+
+// Load mengisi dst dengan urutan prioritas dari rendah ke tinggi:
+//
+//  1. tag `default:"..."`, hanya untuk field yang masih kosong
+//  2. file YAML/JSON dari WithFile
+//  3. file .env dari WithEnvFile
+//  4. environment process, dicari dengan tag `env:"NAME"`; jika kosong, dibaca dari file
+//     yang path-nya ada di NAME_FILE (lihat support.LookupEnv)
+//
+// Nested struct diproses rekursif; tag `envPrefix:"DB_"` menambahkan prefix untuk field di dalamnya.
+// Semua error parse dikumpulkan dan dikembalikan sekaligus. Jika tidak ada error parse,
+// tag `validate` dijalankan dan pelanggarannya dikembalikan sebagai validation.Errors.
+func Load(dst any, opts ...Option) error {
+	o := options{lookup: support.LookupEnv}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	var errs []error
+
+	walk(v.Elem(), "", o.prefix, func(f field) {
+		if f.defaultValue != "" && f.value.IsZero() {
+			if err := setValue(f.value, f.defaultValue); err != nil {
+				errs = append(errs, &FieldError{Field: f.path, Source: "default", Value: f.defaultValue, Err: err})
+			}
+		}
+	})
+
+	for _, path := range o.files {
+		if err := decodeFile(path, dst); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	dotenv := make(map[string]string)
+	for _, path := range o.envFiles {
+		values, err := ReadEnvFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config: %w", err))
+			continue
+		}
+		for key, value := range values {
+			dotenv[key] = value
+		}
+	}
+
+	walk(v.Elem(), "", o.prefix, func(f field) {
+		if f.env == "" {
+			return
+		}
+
+		// Sama seperti support.GetEnv: value kosong dianggap tidak di-set
+		raw, ok, err := o.lookup(f.env)
+		if err != nil {
+			errs = append(errs, &FieldError{Field: f.path, Source: "env " + f.env + "_FILE", Err: err})
+			return
+		}
+		if !ok || raw == "" {
+			if raw, ok = dotenv[f.env]; !ok || raw == "" {
+				return
+			}
+		}
+
+		if err := setValue(f.value, raw); err != nil {
+			errs = append(errs, &FieldError{Field: f.path, Source: "env " + f.env, Value: raw, Err: err})
+		}
+	})
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if verrs := validate(v.Elem(), ""); verrs.HasErrors() {
+		return verrs
+	}
+	return nil
+}
+
+// MustLoad seperti Load tetapi panic jika gagal, untuk dipakai saat startup
+func MustLoad(dst any, opts ...Option) {
+	if err := Load(dst, opts...); err != nil {
+		panic(err)
+	}
+}
+
+func decodeFile(path string, dst any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, dst)
+	case ".json":
+		err = json.Unmarshal(data, dst)
+	default:
+		return fmt.Errorf("config: %s: unsupported file type", path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	return nil
+}
+
+// validate menjalankan validation.ValidateStruct pada struct dan semua nested struct-nya
+func validate(v reflect.Value, path string) validation.Errors {
+	var errs validation.Errors
+	for _, err := range validation.ValidateStruct(v.Interface()) {
+		err.Field = path + err.Field
+		errs = append(errs, err)
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.IsExported() && isNested(sf.Type) {
+			errs = append(errs, validate(v.Field(i), path+sf.Name+".")...)
+		}
+	}
+	return errs
+}
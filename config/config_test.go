@@ -0,0 +1,150 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/config"
+	"github.com/fatkulnurk/foundation/support"
+	"github.com/fatkulnurk/foundation/validation"
+)
+
+type DatabaseConfig struct {
+	Host string `env:"HOST" default:"localhost" yaml:"host"`
+	Port int    `env:"PORT" default:"5432" yaml:"port" validate:"nummin=1,nummax=65535"`
+}
+
+type AppConfig struct {
+	Name     string         `env:"APP_NAME" default:"foundation" yaml:"name" validate:"required"`
+	Debug    bool           `env:"APP_DEBUG" yaml:"debug"`
+	Timeout  time.Duration  `env:"APP_TIMEOUT" default:"5s" yaml:"timeout"`
+	Hosts    []string       `env:"APP_HOSTS" yaml:"hosts"`
+	Database DatabaseConfig `envPrefix:"DB_" yaml:"database"`
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func lookup(env map[string]string) config.Option {
+	return config.WithLookup(func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	})
+}
+
+func TestLoadLayers(t *testing.T) {
+	yamlFile := writeFile(t, "config.yaml", "name: from-yaml\ntimeout: 10s\ndatabase:\n  host: db.internal\n  port: 6543\n")
+	envFile := writeFile(t, ".env", "# local overrides\nexport DB_PORT=7000\nAPP_HOSTS=\"a.test, b.test\"\n")
+
+	var cfg AppConfig
+	err := config.Load(&cfg,
+		config.WithFile(yamlFile),
+		config.WithEnvFile(envFile, filepath.Join(t.TempDir(), "missing.env")),
+		lookup(map[string]string{"APP_DEBUG": "true", "DB_PORT": "8000"}),
+	)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.Name != "from-yaml" || cfg.Timeout != 10*time.Second || cfg.Database.Host != "db.internal" {
+		t.Errorf("Expected YAML values over defaults, got %+v", cfg)
+	}
+	if !cfg.Debug || cfg.Database.Port != 8000 {
+		t.Errorf("Expected process env to win, got %+v", cfg)
+	}
+	if strings.Join(cfg.Hosts, ",") != "a.test,b.test" {
+		t.Errorf("Expected hosts from .env, got %v", cfg.Hosts)
+	}
+}
+
+func TestLoadReportsAllParseErrors(t *testing.T) {
+	var cfg AppConfig
+	err := config.Load(&cfg, lookup(map[string]string{
+		"APP_DEBUG":   "maybe",
+		"APP_TIMEOUT": "soon",
+		"DB_PORT":     "abc",
+	}))
+
+	var fieldErr *config.FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("Expected FieldError, got %v", err)
+	}
+	for _, want := range []string{"Debug", "Timeout", "Database.Port: invalid value \"abc\" from env DB_PORT"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in error:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadValidates(t *testing.T) {
+	var cfg AppConfig
+	err := config.Load(&cfg, lookup(map[string]string{"DB_PORT": "70000"}))
+
+	var verrs validation.Errors
+	if !errors.As(err, &verrs) || len(verrs.ForField("Database.Port")) != 1 {
+		t.Errorf("Expected validation error for Database.Port, got %v", err)
+	}
+}
+
+func TestLoadInvalidTarget(t *testing.T) {
+	var cfg AppConfig
+	if err := config.Load(cfg); !errors.Is(err, config.ErrInvalidTarget) {
+		t.Errorf("Expected ErrInvalidTarget, got %v", err)
+	}
+}
+
+func TestReadEnvFile(t *testing.T) {
+	path := writeFile(t, ".env", "A=plain # comment\nB=\"line\\nbreak\"\nC='raw\\n'\nD=\n")
+
+	values, err := config.ReadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["A"] != "plain" || values["B"] != "line\nbreak" || values["C"] != `raw\n` || values["D"] != "" {
+		t.Errorf("Unexpected values %q", values)
+	}
+
+	if _, err := config.ReadEnvFile(writeFile(t, "bad.env", "NOVALUE\n")); err == nil {
+		t.Error("Expected error for line without =")
+	}
+}
+
+func TestLoadSecretFromFile(t *testing.T) {
+	type SMTPConfig struct {
+		Password support.Secret `env:"SMTP_PASSWORD" validate:"required"`
+	}
+
+	t.Setenv("SMTP_PASSWORD_FILE", writeFile(t, "smtp_password", "s3cret\n"))
+
+	var cfg SMTPConfig
+	if err := config.Load(&cfg); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Password.Reveal() != "s3cret" {
+		t.Errorf("Expected secret from file, got %q", cfg.Password.Reveal())
+	}
+}
+
+func TestLoadRequiredSecret(t *testing.T) {
+	type SMTPConfig struct {
+		Password support.Secret `env:"SMTP_PASSWORD" validate:"required"`
+	}
+
+	var cfg SMTPConfig
+	err := config.Load(&cfg, lookup(nil))
+
+	var verrs validation.Errors
+	if !errors.As(err, &verrs) || len(verrs.ForField("Password")) != 1 {
+		t.Errorf("Expected required error for empty secret, got %v", err)
+	}
+}
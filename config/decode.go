@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+type field struct {
+	value        reflect.Value
+	path         string
+	env          string
+	defaultValue string
+}
+
+// walk memanggil fn untuk setiap field exported yang bukan nested struct
+func walk(v reflect.Value, path, prefix string, fn func(field)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		if isNested(sf.Type) {
+			walk(v.Field(i), path+sf.Name+".", prefix+sf.Tag.Get("envPrefix"), fn)
+			continue
+		}
+
+		f := field{
+			value:        v.Field(i),
+			path:         path + sf.Name,
+			defaultValue: sf.Tag.Get("default"),
+		}
+		if name := sf.Tag.Get("env"); name != "" && name != "-" {
+			f.env = prefix + name
+		}
+		fn(f)
+	}
+}
+
+// isNested mengecek apakah field berupa struct yang field-nya diisi satu per satu
+func isNested(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct &&
+		t != reflect.TypeFor[time.Time]() &&
+		!reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setValue mengisi v dari string. Slice diisi dari daftar yang dipisah koma.
+func setValue(v reflect.Value, raw string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+
+	case reflect.Slice:
+		var parts []string
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(slice.Index(i), part); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		v.Set(slice)
+
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(elem)
+
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
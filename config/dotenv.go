@@ -0,0 +1,92 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadEnvFile membaca file .env menjadi map tanpa mengubah environment process.
+//
+// Format yang didukung:
+//
+//	# komentar
+//	export APP_NAME=billing
+//	DB_HOST = localhost
+//	GREETING="hello\nworld"   # escape \n, \t, \" dan \\ di dalam double quote
+//	RAW='tidak ada escape'
+func ReadEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseEnv(path, data)
+}
+
+func parseEnv(path string, data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNo)
+		}
+
+		value, err := parseEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNo, err)
+		}
+		values[key] = value
+	}
+
+	return values, scanner.Err()
+}
+
+func parseEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	quote := value[0]
+	if quote != '"' && quote != '\'' {
+		// Komentar setelah value tanpa quote harus diawali spasi: KEY=value # komentar
+		if idx := strings.Index(value, " #"); idx >= 0 {
+			value = value[:idx]
+		}
+		return strings.TrimSpace(value), nil
+	}
+
+	end := closingQuote(value, quote)
+	if end < 0 {
+		return "", fmt.Errorf("unterminated %c quote", quote)
+	}
+	if quote == '\'' {
+		return value[1:end], nil
+	}
+
+	return strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value[1:end]), nil
+}
+
+func closingQuote(value string, quote byte) int {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,16 @@
+module github.com/fatkulnurk/foundation/config
+
+go 1.25
+
+require (
+	github.com/fatkulnurk/foundation/support v0.0.0-00010101000000-000000000000
+	github.com/fatkulnurk/foundation/validation v0.0.0-00010101000000-000000000000
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require github.com/google/uuid v1.6.0 // indirect
+
+replace (
+	github.com/fatkulnurk/foundation/support => ../support
+	github.com/fatkulnurk/foundation/validation => ../validation
+)
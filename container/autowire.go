@@ -0,0 +1,150 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ErrMissingDependency is returned by Validate for every constructor parameter that has no registration
+var ErrMissingDependency = errors.New("missing dependency")
+
+var (
+	containerType = reflect.TypeFor[Container]()
+	errorType     = reflect.TypeFor[error]()
+)
+
+// Autowire registers a constructor function. Its parameters are resolved by type
+// (see Provide) when the service is built, and a parameter of type Container receives
+// the container itself. The constructor must return the service, optionally followed by an error:
+//
+//	c.Autowire(container.Singleton, mailer.NewSMTPMailer)
+//
+// The service is registered under the key of its return type, or under name when one is given.
+// Autowire panics when constructor is not a function with that shape.
+func (c *Locator) Autowire(lifetime Lifetime, constructor interface{}, name ...string) {
+	fn := reflect.ValueOf(constructor)
+	fnType := reflect.TypeOf(constructor)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		panic(fmt.Sprintf("container: Autowire expects a function, got %s", typeName(fnType)))
+	}
+	if fnType.IsVariadic() {
+		panic(fmt.Sprintf("container: Autowire does not support variadic constructor %s", fnType))
+	}
+	if n := fnType.NumOut(); n == 0 || n > 2 || (n == 2 && fnType.Out(1) != errorType) {
+		panic(fmt.Sprintf("container: constructor %s must return (T) or (T, error)", fnType))
+	}
+
+	key := typeKey(fnType.Out(0))
+	if len(name) > 0 && name[0] != "" {
+		key = name[0]
+	}
+
+	params := make([]reflect.Type, fnType.NumIn())
+	var deps []string
+	for i := range params {
+		params[i] = fnType.In(i)
+		if params[i] != containerType {
+			deps = append(deps, typeKey(params[i]))
+		}
+	}
+
+	factory := func(c Container) (interface{}, error) {
+		args := make([]reflect.Value, len(params))
+		for i, param := range params {
+			if param == containerType {
+				args[i] = reflect.ValueOf(&c).Elem()
+				continue
+			}
+
+			dep, err := c.Get(typeKey(param))
+			if err != nil {
+				return nil, err
+			}
+
+			value := reflect.ValueOf(dep)
+			switch {
+			case !value.IsValid():
+				args[i] = reflect.Zero(param)
+			case value.Type().AssignableTo(param):
+				args[i] = value
+			default:
+				return nil, fmt.Errorf("%w: parameter %d is %s, expected %s",
+					ErrTypeMismatch, i, typeName(value.Type()), typeName(param))
+			}
+		}
+
+		out := fn.Call(args)
+		if len(out) == 2 && !out[1].IsNil() {
+			return nil, out[1].Interface().(error)
+		}
+		return out[0].Interface(), nil
+	}
+
+	c.setEntry(key, &factoryEntry{lifetime: lifetime, factory: factory, deps: deps})
+}
+
+// Validate checks every constructor registered with Autowire in this container and its
+// parents, and reports all missing dependencies and dependency cycles at once.
+// Call it before the application starts.
+func (c *Locator) Validate() error {
+	entries := make(map[string]*factoryEntry)
+	for l := c; l != nil; l = l.parent {
+		l.mu.RLock()
+		for name, entry := range l.factories {
+			if _, shadowed := entries[name]; !shadowed {
+				entries[name] = entry
+			}
+		}
+		l.mu.RUnlock()
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var errs []error
+	for _, name := range names {
+		for _, dep := range entries[name].deps {
+			if !c.Has(dep) {
+				errs = append(errs, fmt.Errorf("%w: service '%s' requires '%s'", ErrMissingDependency, name, dep))
+			}
+		}
+	}
+
+	// Depth-first search for cycles between autowired constructors
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var visit func(name string, path []string)
+	visit = func(name string, path []string) {
+		switch state[name] {
+		case visiting:
+			cycle := append(slices.Clone(path[slices.Index(path, name):]), name)
+			errs = append(errs, fmt.Errorf("%w: %s", ErrCircularDependency, strings.Join(cycle, " -> ")))
+			return
+		case done:
+			return
+		}
+
+		state[name] = visiting
+		if entry, ok := entries[name]; ok {
+			for _, dep := range entry.deps {
+				visit(dep, append(path, name))
+			}
+		}
+		state[name] = done
+	}
+	for _, name := range names {
+		visit(name, nil)
+	}
+
+	return errors.Join(errs...)
+}
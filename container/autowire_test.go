@@ -0,0 +1,106 @@
+package container
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type UserRepo struct {
+	DB *Database
+}
+
+type UserService struct {
+	Repo     *UserRepo
+	Notifier Notifier
+	Scope    Container
+}
+
+func NewUserRepo(db *Database) *UserRepo {
+	return &UserRepo{DB: db}
+}
+
+func NewUserService(repo *UserRepo, notifier Notifier, c Container) (*UserService, error) {
+	if repo.DB == nil {
+		return nil, errors.New("repo without database")
+	}
+	return &UserService{Repo: repo, Notifier: notifier, Scope: c}, nil
+}
+
+func TestAutowire(t *testing.T) {
+	c := New()
+	Provide(c, &Database{Name: "main"})
+	Provide[Notifier](c, emailNotifier{})
+	c.Autowire(Singleton, NewUserRepo)
+	c.Autowire(Transient, NewUserService)
+
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Expected valid container, got %v", err)
+	}
+
+	svc, err := Resolve[*UserService](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve autowired service: %v", err)
+	}
+	if svc.Repo.DB.Name != "main" || svc.Notifier == nil || svc.Scope == nil {
+		t.Errorf("Expected dependencies to be injected, got %+v", svc)
+	}
+	if MustResolve[*UserRepo](c) != svc.Repo {
+		t.Error("Expected singleton repo to be shared")
+	}
+}
+
+func TestAutowireConstructorError(t *testing.T) {
+	c := New()
+	Provide(c, &UserRepo{})
+	Provide[Notifier](c, emailNotifier{})
+	c.Autowire(Singleton, NewUserService)
+
+	if _, err := Resolve[*UserService](c); err == nil || !strings.Contains(err.Error(), "repo without database") {
+		t.Errorf("Expected constructor error, got %v", err)
+	}
+}
+
+func TestAutowireInvalidConstructor(t *testing.T) {
+	for name, ctor := range map[string]interface{}{
+		"not a function": &Database{},
+		"no result":      func(*Database) {},
+		"bad error":      func() (*Database, string) { return nil, "" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic for invalid constructor")
+				}
+			}()
+			New().Autowire(Singleton, ctor)
+		})
+	}
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	type A struct{}
+	type B struct{}
+
+	c := New()
+	c.Autowire(Singleton, NewUserRepo)
+	c.Autowire(Singleton, NewUserService)
+	c.Autowire(Singleton, func(*B) *A { return &A{} })
+	c.Autowire(Singleton, func(*A) *B { return &B{} })
+
+	err := c.Validate()
+	if !errors.Is(err, ErrMissingDependency) || !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("Expected missing and circular dependency errors, got %v", err)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"requires 'type:*github.com/fatkulnurk/foundation/container.Database'",
+		"requires 'type:github.com/fatkulnurk/foundation/container.Notifier'",
+		"circular dependency",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected %q in error:\n%s", want, msg)
+		}
+	}
+}
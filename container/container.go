@@ -1,9 +1,6 @@
 package container
 
-import (
-	"fmt"
-	"sync"
-)
+import "sync"
 
 // Container defines the interface for service locator pattern
 type Container interface {
@@ -22,8 +19,16 @@ type Container interface {
 
 // Locator is a simple service locator
 type Locator struct {
-	mu       sync.RWMutex
-	services map[string]interface{}
+	mu        sync.RWMutex
+	services  map[string]interface{}
+	factories map[string]*factoryEntry
+
+	// created lists disposable services in creation order, for Shutdown
+	created []createdService
+
+	// parent and scoped are only set on scopes created with NewScope
+	parent *Locator
+	scoped map[string]*lazyInstance
 }
 
 // Compile-time check to ensure Locator implements Container
@@ -32,7 +37,8 @@ var _ Container = (*Locator)(nil)
 // New creates a new Locator
 func New() *Locator {
 	return &Locator{
-		services: make(map[string]interface{}),
+		services:  make(map[string]interface{}),
+		factories: make(map[string]*factoryEntry),
 	}
 }
 
@@ -41,24 +47,35 @@ func NewContainer() Container {
 	return New()
 }
 
-// Set registers a service with the given name
+// Set registers a service with the given name. The container owns service: Shutdown
+// disposes it when it implements io.Closer or Stopper. A service previously registered
+// or built under the same name is forgotten and not disposed.
 func (c *Locator) Set(name string, service interface{}) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.services[name] = service
+	c.setService(name, service)
+	c.track(name, service)
 }
 
-// Get retrieves a service by name
-func (c *Locator) Get(name string) (interface{}, error) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// SetShared registers a service like Set, but the caller keeps ownership: Shutdown and
+// scope Close never dispose it. Use it to override a service in a scope with an object
+// that outlives the scope, such as a shared connection pool.
+func (c *Locator) SetShared(name string, service interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setService(name, service)
+}
 
-	service, exists := c.services[name]
-	if !exists {
-		return nil, fmt.Errorf("service '%s' not found", name)
-	}
+// setService replaces any registration under name. The caller must hold c.mu.
+func (c *Locator) setService(name string, service interface{}) {
+	c.services[name] = service
+	delete(c.factories, name)
+	c.untrack(name)
+}
 
-	return service, nil
+// Get retrieves a service by name, building it first when it was registered with a factory
+func (c *Locator) Get(name string) (interface{}, error) {
+	return c.resolve(&chain{}, name, nil)
 }
 
 // MustGet retrieves a service and panics if not found
@@ -70,10 +87,17 @@ func (c *Locator) MustGet(name string) interface{} {
 	return service
 }
 
-// Has checks if a service exists
+// Has checks if a service exists, either as an instance or as a factory
 func (c *Locator) Has(name string) bool {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	_, exists := c.services[name]
-	return exists
+	for l := c; l != nil; l = l.parent {
+		l.mu.RLock()
+		_, isService := l.services[name]
+		_, isFactory := l.factories[name]
+		l.mu.RUnlock()
+
+		if isService || isFactory {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,308 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrCircularDependency is returned when a service depends on itself through its factories
+	ErrCircularDependency = errors.New("circular dependency")
+
+	// ErrNoScope is returned when a scoped service is resolved outside a scope
+	ErrNoScope = errors.New("scoped service resolved outside a scope")
+
+	// ErrFactoryPanic is returned to callers waiting on a build whose factory panicked
+	ErrFactoryPanic = errors.New("factory panicked")
+)
+
+// Factory builds a service. The given Container resolves the service's dependencies.
+type Factory func(c Container) (interface{}, error)
+
+// Lifetime controls how often a factory is called
+type Lifetime int
+
+const (
+	// Singleton builds the service once, on first resolve, and shares it everywhere
+	Singleton Lifetime = iota
+
+	// Transient builds a new service on every resolve
+	Transient
+
+	// Scoped builds the service once per scope (see NewScope)
+	Scoped
+)
+
+// String returns the lifetime name
+func (l Lifetime) String() string {
+	switch l {
+	case Singleton:
+		return "singleton"
+	case Transient:
+		return "transient"
+	case Scoped:
+		return "scoped"
+	default:
+		return fmt.Sprintf("Lifetime(%d)", int(l))
+	}
+}
+
+type factoryEntry struct {
+	lifetime Lifetime
+	factory  Factory
+	instance lazyInstance
+
+	// deps is only known for constructors registered with Autowire
+	deps []string
+}
+
+// lazyInstance builds a value once. Unlike sync.Once, a failed build is retried on the next call.
+// The factory runs outside the lock; concurrent callers wait for the build in flight.
+type lazyInstance struct {
+	mu    sync.Mutex
+	built bool
+	value interface{}
+	call  *buildCall
+}
+
+// buildCall is a build in flight, started by owner
+type buildCall struct {
+	owner *chain
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// chain is one top-level resolution and every nested resolve made by its factories.
+// waitingOn is the build of another chain it is blocked on.
+type chain struct {
+	waitingOn *buildCall
+}
+
+// waitMu guards chain.waitingOn, so deciding to wait and recording it happen together
+var waitMu sync.Mutex
+
+// wait blocks until call finishes. Two chains that wait on each other's builds, such as two
+// singletons resolving each other from different goroutines, would deadlock, so the chain
+// that closes the loop gets ErrCircularDependency instead.
+func (ch *chain) wait(call *buildCall, path []string) (interface{}, error) {
+	waitMu.Lock()
+	for c := call.owner; c != nil; {
+		if c == ch {
+			waitMu.Unlock()
+			return nil, fmt.Errorf("%w: %s (built concurrently)", ErrCircularDependency, strings.Join(path, " -> "))
+		}
+		if c.waitingOn == nil {
+			break
+		}
+		c = c.waitingOn.owner
+	}
+	ch.waitingOn = call
+	waitMu.Unlock()
+
+	<-call.done
+
+	waitMu.Lock()
+	ch.waitingOn = nil
+	waitMu.Unlock()
+
+	return call.value, call.err
+}
+
+func (li *lazyInstance) get(ch *chain, path []string, build func() (interface{}, error)) (interface{}, error) {
+	li.mu.Lock()
+	if li.built {
+		li.mu.Unlock()
+		return li.value, nil
+	}
+	if call := li.call; call != nil {
+		li.mu.Unlock()
+		return ch.wait(call, path)
+	}
+
+	call := &buildCall{owner: ch, done: make(chan struct{})}
+	li.call = call
+	li.mu.Unlock()
+
+	// A factory may panic, e.g. through MustGet. Waiting callers must still be released
+	// and the next resolve must build again, so the build in flight is always finished.
+	defer func() {
+		if r := recover(); r != nil {
+			call.err = fmt.Errorf("%w: %s: %v", ErrFactoryPanic, strings.Join(path, " -> "), r)
+			li.finish(call)
+			panic(r)
+		}
+	}()
+
+	call.value, call.err = build()
+	li.finish(call)
+
+	return call.value, call.err
+}
+
+// finish stores the result of call and releases the callers waiting on it
+func (li *lazyInstance) finish(call *buildCall) {
+	li.mu.Lock()
+	if call.err == nil {
+		li.value = call.value
+		li.built = true
+	}
+	li.call = nil
+	li.mu.Unlock()
+	close(call.done)
+}
+
+// reset forgets the built value, so the next get builds a new one
+func (li *lazyInstance) reset() {
+	li.mu.Lock()
+	defer li.mu.Unlock()
+	li.built = false
+	li.value = nil
+}
+
+// SetFactory registers a factory that builds the service lazily with the given lifetime.
+// It replaces any service or factory registered under the same name.
+func (c *Locator) SetFactory(name string, lifetime Lifetime, factory Factory) {
+	c.setEntry(name, &factoryEntry{lifetime: lifetime, factory: factory})
+}
+
+func (c *Locator) setEntry(name string, entry *factoryEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.factories[name] = entry
+	delete(c.services, name)
+	c.untrack(name)
+}
+
+// Singleton registers a factory with the Singleton lifetime
+func (c *Locator) Singleton(name string, factory Factory) {
+	c.SetFactory(name, Singleton, factory)
+}
+
+// Transient registers a factory with the Transient lifetime
+func (c *Locator) Transient(name string, factory Factory) {
+	c.SetFactory(name, Transient, factory)
+}
+
+// Scoped registers a factory with the Scoped lifetime
+func (c *Locator) Scoped(name string, factory Factory) {
+	c.SetFactory(name, Scoped, factory)
+}
+
+// ProvideFactory registers a typed factory under the key of type T, or under name when one is given
+func ProvideFactory[T any](c *Locator, lifetime Lifetime, factory func(c Container) (T, error), name ...string) {
+	c.SetFactory(serviceKey[T](name), lifetime, func(c Container) (interface{}, error) {
+		return factory(c)
+	})
+}
+
+// resolve looks up name in this container and its parents. path holds the services
+// currently being built by ch, so a factory that needs one of them is reported as a cycle.
+func (c *Locator) resolve(ch *chain, name string, path []string) (interface{}, error) {
+	if slices.Contains(path, name) {
+		return nil, fmt.Errorf("%w: %s", ErrCircularDependency, strings.Join(append(path, name), " -> "))
+	}
+
+	for owner := c; owner != nil; owner = owner.parent {
+		owner.mu.RLock()
+		service, isService := owner.services[name]
+		entry, isFactory := owner.factories[name]
+		owner.mu.RUnlock()
+
+		if isService {
+			return service, nil
+		}
+		if isFactory {
+			return c.build(ch, owner, name, entry, path)
+		}
+	}
+
+	if len(path) > 0 {
+		return nil, fmt.Errorf("service '%s' not found (required by %s)", name, strings.Join(path, " -> "))
+	}
+	return nil, fmt.Errorf("service '%s' not found", name)
+}
+
+func (c *Locator) build(ch *chain, owner *Locator, name string, entry *factoryEntry, path []string) (interface{}, error) {
+	path = append(slices.Clip(path), name)
+
+	// Singletons resolve their dependencies from the container that owns them,
+	// so they never capture a scoped service of the scope that happened to ask first
+	call := func(from *Locator) func() (interface{}, error) {
+		return func() (interface{}, error) {
+			service, err := entry.factory(&resolver{locator: from, chain: ch, path: path})
+			if err != nil {
+				return nil, fmt.Errorf("build service '%s': %w", name, err)
+			}
+			return service, nil
+		}
+	}
+
+	switch entry.lifetime {
+	case Transient:
+		return call(c)()
+	case Scoped:
+		if c.parent == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNoScope, strings.Join(path, " -> "))
+		}
+		return c.scopedInstance(name).get(ch, path, tracked(c, name, call(c)))
+	default:
+		return entry.instance.get(ch, path, tracked(owner, name, call(owner)))
+	}
+}
+
+// tracked records a built service on l, so l's Shutdown disposes it
+func tracked(l *Locator, name string, build func() (interface{}, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		service, err := build()
+		if err == nil {
+			l.mu.Lock()
+			l.track(name, service)
+			l.mu.Unlock()
+		}
+		return service, err
+	}
+}
+
+func (c *Locator) scopedInstance(name string) *lazyInstance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	instance, ok := c.scoped[name]
+	if !ok {
+		instance = &lazyInstance{}
+		c.scoped[name] = instance
+	}
+	return instance
+}
+
+// resolver is the Container given to factories. It carries the resolution path
+// so circular dependencies are reported instead of deadlocking.
+type resolver struct {
+	locator *Locator
+	chain   *chain
+	path    []string
+}
+
+func (r *resolver) Set(name string, service interface{}) {
+	r.locator.Set(name, service)
+}
+
+func (r *resolver) Get(name string) (interface{}, error) {
+	return r.locator.resolve(r.chain, name, r.path)
+}
+
+func (r *resolver) MustGet(name string) interface{} {
+	service, err := r.Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+func (r *resolver) Has(name string) bool {
+	return r.locator.Has(name)
+}
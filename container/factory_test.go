@@ -0,0 +1,224 @@
+package container
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingletonFactory(t *testing.T) {
+	c := New()
+	var calls atomic.Int32
+
+	c.Singleton("database", func(c Container) (interface{}, error) {
+		calls.Add(1)
+		return &Database{Name: "lazy"}, nil
+	})
+
+	if calls.Load() != 0 {
+		t.Fatal("Expected factory not to run at registration")
+	}
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.MustGet("database")
+		}()
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected factory to run once, ran %d times", calls.Load())
+	}
+	for _, r := range results {
+		if r != results[0] {
+			t.Fatal("Expected same instance for every resolve")
+		}
+	}
+}
+
+func TestSingletonFactoryRetriesAfterError(t *testing.T) {
+	c := New()
+	fail := true
+
+	c.Singleton("database", func(c Container) (interface{}, error) {
+		if fail {
+			return nil, errors.New("connection refused")
+		}
+		return &Database{}, nil
+	})
+
+	if _, err := c.Get("database"); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Fatalf("Expected factory error, got %v", err)
+	}
+
+	fail = false
+	if _, err := c.Get("database"); err != nil {
+		t.Fatalf("Expected retry to succeed, got %v", err)
+	}
+}
+
+func TestTransientFactory(t *testing.T) {
+	c := New()
+	c.Transient("logger", func(c Container) (interface{}, error) {
+		return &Logger{}, nil
+	})
+
+	if c.MustGet("logger") == c.MustGet("logger") {
+		t.Error("Expected a new instance for every resolve")
+	}
+}
+
+func TestScopedFactory(t *testing.T) {
+	c := New()
+	c.Scoped("logger", func(c Container) (interface{}, error) {
+		return &Logger{}, nil
+	})
+
+	if _, err := c.Get("logger"); !errors.Is(err, ErrNoScope) {
+		t.Fatalf("Expected ErrNoScope from root container, got %v", err)
+	}
+
+	first := c.NewScope()
+	second := c.NewScope()
+
+	if first.MustGet("logger") != first.MustGet("logger") {
+		t.Error("Expected same instance within a scope")
+	}
+	if first.MustGet("logger") == second.MustGet("logger") {
+		t.Error("Expected different instances across scopes")
+	}
+}
+
+func TestFactoryDependencies(t *testing.T) {
+	c := New()
+	c.Set("logger", &Logger{Level: "debug"})
+	ProvideFactory(c, Singleton, func(c Container) (*EmailService, error) {
+		logger, err := Resolve[*Logger](c, "logger")
+		if err != nil {
+			return nil, err
+		}
+		return &EmailService{logger: logger}, nil
+	})
+
+	svc := MustResolve[*EmailService](c)
+	if svc.logger.Level != "debug" {
+		t.Errorf("Expected logger to be injected, got %+v", svc.logger)
+	}
+}
+
+func TestCircularDependency(t *testing.T) {
+	c := New()
+	c.Singleton("a", func(c Container) (interface{}, error) { return c.Get("b") })
+	c.Transient("b", func(c Container) (interface{}, error) { return c.Get("c") })
+	c.Singleton("c", func(c Container) (interface{}, error) { return c.Get("a") })
+
+	_, err := c.Get("a")
+	if !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("Expected ErrCircularDependency, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("Expected resolution path in error, got %q", err)
+	}
+}
+
+func TestConcurrentCircularSingletons(t *testing.T) {
+	c := New()
+
+	// Both factories start before either resolves its dependency
+	var started sync.WaitGroup
+	started.Add(2)
+	factory := func(dep string) Factory {
+		return func(c Container) (interface{}, error) {
+			started.Done()
+			started.Wait()
+			return c.Get(dep)
+		}
+	}
+	c.Singleton("a", factory("b"))
+	c.Singleton("b", factory("a"))
+
+	errs := make(chan error, 2)
+	for _, name := range []string{"a", "b"} {
+		go func() {
+			_, err := c.Get(name)
+			errs <- err
+		}()
+	}
+
+	for range 2 {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, ErrCircularDependency) {
+				t.Errorf("Expected ErrCircularDependency, got %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Deadlock: singletons resolving each other did not return")
+		}
+	}
+}
+
+func TestSingletonFactoryPanicReleasesBuild(t *testing.T) {
+	c := New()
+	var builds atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	c.Singleton("report", func(c Container) (interface{}, error) {
+		if builds.Add(1) == 1 {
+			close(started)
+			<-release
+			c.MustGet("missing")
+		}
+		return &Database{Name: "report"}, nil
+	})
+
+	panicked := make(chan interface{})
+	go func() {
+		defer func() { panicked <- recover() }()
+		c.Get("report")
+	}()
+	<-started
+
+	// A caller that waits on the panicking build gets an error instead of blocking forever
+	waiter := make(chan error)
+	go func() {
+		_, err := c.Get("report")
+		waiter <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if r := <-panicked; r == nil {
+		t.Fatal("Expected the factory panic to reach the caller")
+	}
+
+	select {
+	case err := <-waiter:
+		// The waiter may also have started its own build after the first one finished
+		if err != nil && !errors.Is(err, ErrFactoryPanic) {
+			t.Errorf("Expected ErrFactoryPanic, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Waiter blocked after the factory panicked")
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := c.Get("report")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected a new build after the panic, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Get blocked after the factory panicked")
+	}
+}
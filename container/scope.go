@@ -0,0 +1,80 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrNoContainer is returned when a context does not carry a container
+var ErrNoContainer = errors.New("no container in context")
+
+type contextKey struct{}
+
+// NewScope creates a child container. The scope sees every registration of its parent,
+// keeps its own instances of Scoped services, and can override services with Set or SetShared.
+// Call Close when the scope ends to dispose its services.
+func (c *Locator) NewScope() *Locator {
+	scope := New()
+	scope.parent = c
+	scope.scoped = make(map[string]*lazyInstance)
+	return scope
+}
+
+// Close disposes the services of this scope: the Scoped services it built and the
+// services Set on it, but not those registered with SetShared. It is Shutdown without a deadline.
+func (c *Locator) Close() error {
+	return c.Shutdown(context.Background())
+}
+
+// WithContext returns a copy of ctx that carries c
+func WithContext(ctx context.Context, c Container) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the container attached to ctx with WithContext
+func FromContext(ctx context.Context) (Container, bool) {
+	c, ok := ctx.Value(contextKey{}).(Container)
+	return c, ok
+}
+
+// ResolveContext resolves a service from the container attached to ctx
+func ResolveContext[T any](ctx context.Context, name ...string) (T, error) {
+	c, ok := FromContext(ctx)
+	if !ok {
+		var zero T
+		return zero, ErrNoContainer
+	}
+	return Resolve[T](c, name...)
+}
+
+// Middleware creates a scope of c for every HTTP request and attaches it to the request context.
+// The scope is closed after the handler returns; close errors are passed to onCloseError when given.
+//
+//	r.Use(container.Middleware(c, nil))
+func Middleware(c *Locator, onCloseError func(r *http.Request, err error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := c.NewScope()
+			defer func() {
+				if err := scope.Close(); err != nil && onCloseError != nil {
+					onCloseError(r, err)
+				}
+			}()
+
+			next.ServeHTTP(w, r.WithContext(WithContext(r.Context(), scope)))
+		})
+	}
+}
+
+// WrapTask runs next with a fresh scope of c attached to the context, for queue handlers.
+// Close errors of the scope are joined with the handler error.
+//
+//	q.Register("send_email", container.WrapTask(c, sendEmailHandler))
+func WrapTask(c *Locator, next func(ctx context.Context, payload []byte) error) func(ctx context.Context, payload []byte) error {
+	return func(ctx context.Context, payload []byte) error {
+		scope := c.NewScope()
+		err := next(WithContext(ctx, scope), payload)
+		return errors.Join(err, scope.Close())
+	}
+}
@@ -0,0 +1,100 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type closeRecorder struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (c *closeRecorder) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+func TestScopeInheritsAndOverrides(t *testing.T) {
+	root := New()
+	root.Set("logger", &Logger{Level: "info"})
+	root.Set("database", &Database{Name: "main"})
+
+	scope := root.NewScope()
+	scope.Set("logger", &Logger{Level: "debug"})
+
+	if MustResolve[*Logger](scope, "logger").Level != "debug" {
+		t.Error("Expected scope override")
+	}
+	if MustResolve[*Logger](root, "logger").Level != "info" {
+		t.Error("Expected override not to leak into parent")
+	}
+	if MustResolve[*Database](scope, "database").Name != "main" {
+		t.Error("Expected scope to inherit parent registration")
+	}
+}
+
+func TestScopeCloseDisposesInReverseOrder(t *testing.T) {
+	var closed []string
+	root := New()
+	root.Scoped("tx", func(c Container) (interface{}, error) {
+		return &closeRecorder{name: "tx", closed: &closed, err: errors.New("rollback failed")}, nil
+	})
+	root.Scoped("repo", func(c Container) (interface{}, error) {
+		c.MustGet("tx")
+		return &closeRecorder{name: "repo", closed: &closed}, nil
+	})
+
+	scope := root.NewScope()
+	scope.MustGet("repo")
+
+	err := scope.Close()
+	if err == nil || err.Error() != "shutdown service 'tx': rollback failed" {
+		t.Errorf("Expected close error to be returned, got %v", err)
+	}
+	if len(closed) != 2 || closed[0] != "repo" || closed[1] != "tx" {
+		t.Errorf("Expected reverse creation order [repo tx], got %v", closed)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var closed []string
+	root := New()
+	root.Scoped("logger", func(c Container) (interface{}, error) {
+		return &closeRecorder{name: "logger", closed: &closed}, nil
+	})
+
+	handler := Middleware(root, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := ResolveContext[*closeRecorder](r.Context(), "logger"); err != nil {
+			t.Errorf("Failed to resolve from request context: %v", err)
+		}
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if len(closed) != 1 {
+		t.Errorf("Expected scoped service to be closed after request, got %v", closed)
+	}
+}
+
+func TestWrapTask(t *testing.T) {
+	root := New()
+	root.Set("logger", &Logger{})
+
+	task := WrapTask(root, func(ctx context.Context, payload []byte) error {
+		_, err := ResolveContext[*Logger](ctx, "logger")
+		return err
+	})
+
+	if err := task(context.Background(), nil); err != nil {
+		t.Errorf("Expected task to resolve from context, got %v", err)
+	}
+
+	if _, err := ResolveContext[*Logger](context.Background()); !errors.Is(err, ErrNoContainer) {
+		t.Errorf("Expected ErrNoContainer, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+)
+
+// Stopper is implemented by services that need a context to shut down, such as servers and workers
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+type createdService struct {
+	name    string
+	service interface{}
+}
+
+// track records a service set on or built by c for Shutdown when it can be disposed. The caller must hold c.mu.
+func (c *Locator) track(name string, service interface{}) {
+	switch service.(type) {
+	case Stopper, io.Closer:
+		c.created = append(c.created, createdService{name: name, service: service})
+	}
+}
+
+// untrack forgets the service registered or built under name, because it was replaced. The caller must hold c.mu.
+func (c *Locator) untrack(name string) {
+	c.created = slices.DeleteFunc(c.created, func(svc createdService) bool {
+		return svc.name == name
+	})
+	if c.scoped != nil {
+		delete(c.scoped, name)
+	}
+}
+
+// Shutdown disposes every service registered with Set and every Singleton and Scoped service
+// built by this container, in reverse creation order, so a service is stopped before the
+// services it depends on. Services implementing Stopper are stopped with ctx; otherwise
+// io.Closer is used. Services registered with SetShared and Transient services are owned
+// by the caller and are not disposed.
+//
+// Built instances are forgotten, so resolving a singleton after Shutdown builds a new one.
+// All errors are joined together. When ctx ends, Shutdown stops waiting and reports
+// the services that were not disposed.
+func (c *Locator) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	created := c.created
+	c.created = nil
+	if c.scoped != nil {
+		c.scoped = make(map[string]*lazyInstance)
+	}
+	for _, entry := range c.factories {
+		if entry.lifetime == Singleton {
+			entry.instance.reset()
+		}
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	disposed := make(map[interface{}]bool)
+	for i := len(created) - 1; i >= 0; i-- {
+		svc := created[i]
+
+		// The same instance may be registered under several names; dispose it once
+		if reflect.TypeOf(svc.service).Comparable() {
+			if disposed[svc.service] {
+				continue
+			}
+			disposed[svc.service] = true
+		}
+
+		if err := dispose(ctx, svc.service); err != nil {
+			errs = append(errs, fmt.Errorf("shutdown service '%s': %w", svc.name, err))
+		}
+
+		if ctx.Err() != nil && i > 0 {
+			errs = append(errs, fmt.Errorf("shutdown aborted, %d services not disposed: %w", i, context.Cause(ctx)))
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// dispose stops service and gives up waiting when ctx ends, because io.Closer cannot be cancelled
+func dispose(ctx context.Context, service interface{}) error {
+	done := make(chan error, 1)
+	go func() {
+		switch s := service.(type) {
+		case Stopper:
+			done <- s.Stop(ctx)
+		case io.Closer:
+			done <- s.Close()
+		}
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
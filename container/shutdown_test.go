@@ -0,0 +1,165 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stopRecorder struct {
+	name    string
+	stopped *[]string
+	delay   time.Duration
+}
+
+func (s *stopRecorder) Stop(ctx context.Context) error {
+	select {
+	case <-time.After(s.delay):
+		*s.stopped = append(*s.stopped, s.name)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close must not be called when Stop is available
+func (s *stopRecorder) Close() error {
+	return errors.New("Close called instead of Stop")
+}
+
+func TestShutdownReverseCreationOrder(t *testing.T) {
+	var order []string
+	c := New()
+	c.Set("redis", &closeRecorder{name: "redis", closed: &order})
+	c.Singleton("queue", func(c Container) (interface{}, error) {
+		c.MustGet("redis")
+		return &stopRecorder{name: "queue", stopped: &order}, nil
+	})
+	c.Singleton("worker", func(c Container) (interface{}, error) {
+		c.MustGet("queue")
+		return &closeRecorder{name: "worker", closed: &order}, nil
+	})
+	c.Transient("job", func(c Container) (interface{}, error) {
+		return &closeRecorder{name: "job", closed: &order}, nil
+	})
+	c.Set("alias.redis", c.MustGet("redis"))
+
+	c.MustGet("worker")
+	c.MustGet("job")
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if strings.Join(order, ",") != "worker,queue,redis" {
+		t.Errorf("Expected worker,queue,redis, got %v", order)
+	}
+}
+
+func TestShutdownAggregatesErrors(t *testing.T) {
+	var order []string
+	c := New()
+	c.Set("a", &closeRecorder{name: "a", closed: &order, err: errors.New("a failed")})
+	c.Set("b", &closeRecorder{name: "b", closed: &order, err: errors.New("b failed")})
+
+	err := c.Shutdown(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "shutdown service 'a': a failed") ||
+		!strings.Contains(err.Error(), "shutdown service 'b': b failed") {
+		t.Errorf("Expected both errors, got %v", err)
+	}
+}
+
+func TestShutdownHonoursDeadline(t *testing.T) {
+	var order []string
+	c := New()
+	c.Set("db", &closeRecorder{name: "db", closed: &order})
+	c.Set("server", &stopRecorder{name: "server", stopped: &order, delay: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := c.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "1 services not disposed") {
+		t.Errorf("Expected skipped services to be reported, got %v", err)
+	}
+	if len(order) != 0 {
+		t.Errorf("Expected nothing disposed after deadline, got %v", order)
+	}
+}
+
+func TestScopeCloseSkipsSharedServices(t *testing.T) {
+	var closed []string
+	shared := &closeRecorder{name: "shared", closed: &closed}
+
+	root := New()
+	for i := range 3 {
+		scope := root.NewScope()
+		scope.SetShared("db", shared)
+		scope.Set("tx", &closeRecorder{name: fmt.Sprintf("tx%d", i), closed: &closed})
+		if err := scope.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+	}
+
+	if strings.Join(closed, ",") != "tx0,tx1,tx2" {
+		t.Errorf("Expected only services Set on the scope to be closed, got %v", closed)
+	}
+}
+
+func TestShutdownForgetsReplacedServices(t *testing.T) {
+	var closed []string
+	c := New()
+	c.Singleton("db", func(c Container) (interface{}, error) {
+		return &closeRecorder{name: "built", closed: &closed}, nil
+	})
+	c.Singleton("cache", func(c Container) (interface{}, error) {
+		return &closeRecorder{name: "cache", closed: &closed}, nil
+	})
+	c.MustGet("db")
+	c.MustGet("cache")
+
+	c.Set("db", &closeRecorder{name: "replacement", closed: &closed})
+	c.Singleton("cache", func(c Container) (interface{}, error) {
+		return &closeRecorder{name: "cache v2", closed: &closed}, nil
+	})
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if strings.Join(closed, ",") != "replacement" {
+		t.Errorf("Expected only the replacement to be disposed, got %v", closed)
+	}
+}
+
+func TestShutdownResetsSingletons(t *testing.T) {
+	var closed []string
+	builds := 0
+	c := New()
+	c.Singleton("db", func(c Container) (interface{}, error) {
+		builds++
+		return &closeRecorder{name: "db", closed: &closed}, nil
+	})
+
+	first := c.MustGet("db")
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	second := c.MustGet("db")
+	if first == second || builds != 2 {
+		t.Fatalf("Expected a new instance after Shutdown, got same=%v builds=%d", first == second, builds)
+	}
+
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if strings.Join(closed, ",") != "db,db" {
+		t.Errorf("Expected each instance closed once, got %v", closed)
+	}
+}
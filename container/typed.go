@@ -0,0 +1,82 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrTypeMismatch is returned when a registered service does not have the requested type
+var ErrTypeMismatch = errors.New("service type mismatch")
+
+// Provide registers service under the key of type T, or under name when one is given.
+// Use an interface type for T to register an implementation by its interface:
+//
+//	container.Provide[Logger](c, slogLogger)
+func Provide[T any](c Container, service T, name ...string) {
+	c.Set(serviceKey[T](name), service)
+}
+
+// Resolve retrieves the service registered for type T, or by name when one is given,
+// and returns an error naming the expected and actual types when it has a different type
+func Resolve[T any](c Container, name ...string) (T, error) {
+	var zero T
+
+	key := serviceKey[T](name)
+	service, err := c.Get(key)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := service.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: service '%s' is %s, expected %s",
+			ErrTypeMismatch, key, typeName(reflect.TypeOf(service)), typeName(reflect.TypeFor[T]()))
+	}
+
+	return typed, nil
+}
+
+// MustResolve is like Resolve but panics on error
+func MustResolve[T any](c Container, name ...string) T {
+	service, err := Resolve[T](c, name...)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+// HasType checks if a service is registered for type T, or by name when one is given
+func HasType[T any](c Container, name ...string) bool {
+	return c.Has(serviceKey[T](name))
+}
+
+// TypeKey returns the key used by Provide and Resolve for type T
+func TypeKey[T any]() string {
+	return typeKey(reflect.TypeFor[T]())
+}
+
+func serviceKey[T any](name []string) string {
+	if len(name) > 0 && name[0] != "" {
+		return name[0]
+	}
+	return TypeKey[T]()
+}
+
+// typeKey builds a key that stays unique across packages with the same name
+func typeKey(t reflect.Type) string {
+	return "type:" + typeName(t)
+}
+
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "<nil>"
+	}
+	if t.Kind() == reflect.Pointer {
+		return "*" + typeName(t.Elem())
+	}
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+	return t.String()
+}
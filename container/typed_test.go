@@ -0,0 +1,79 @@
+package container
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type Notifier interface {
+	Notify(msg string) error
+}
+
+type emailNotifier struct{}
+
+func (emailNotifier) Notify(string) error { return nil }
+
+func TestProvideAndResolve(t *testing.T) {
+	c := New()
+	db := &Database{Name: "primary"}
+
+	Provide(c, db)
+	Provide[Notifier](c, emailNotifier{})
+
+	got, err := Resolve[*Database](c)
+	if err != nil {
+		t.Fatalf("Failed to resolve service: %v", err)
+	}
+	if got != db {
+		t.Error("Expected same instance")
+	}
+
+	if _, err := Resolve[Notifier](c); err != nil {
+		t.Fatalf("Failed to resolve interface: %v", err)
+	}
+
+	if !HasType[*Database](c) || HasType[*Logger](c) {
+		t.Error("Expected HasType to report registered types only")
+	}
+}
+
+func TestResolveByName(t *testing.T) {
+	c := New()
+	c.Set("database", &Database{Name: "legacy"})
+	Provide(c, &Database{Name: "replica"}, "replica")
+
+	legacy := MustResolve[*Database](c, "database")
+	if legacy.Name != "legacy" {
+		t.Errorf("Expected legacy database, got %s", legacy.Name)
+	}
+
+	replica := MustResolve[*Database](c, "replica")
+	if replica.Name != "replica" {
+		t.Errorf("Expected replica database, got %s", replica.Name)
+	}
+}
+
+func TestResolveTypeMismatch(t *testing.T) {
+	c := New()
+	c.Set("database", &Logger{})
+
+	_, err := Resolve[*Database](c, "database")
+	if !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Expected ErrTypeMismatch, got %v", err)
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "*github.com/fatkulnurk/foundation/container.Logger") ||
+		!strings.Contains(msg, "expected *github.com/fatkulnurk/foundation/container.Database") {
+		t.Errorf("Expected error to name both types, got %q", msg)
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	c := New()
+
+	if _, err := Resolve[*Database](c); err == nil {
+		t.Error("Expected error for unregistered type")
+	}
+}
@@ -0,0 +1,335 @@
+package httprouter
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatkulnurk/foundation/validation"
+)
+
+// DefaultMaxBodySize adalah batas ukuran body untuk Bind jika WithMaxBodySize tidak dipakai
+const DefaultMaxBodySize int64 = 1 << 20
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// BindError dikembalikan Bind. Status berisi HTTP status yang sesuai:
+//   - 400 untuk body atau parameter yang tidak bisa di-parse
+//   - 413 untuk body yang melebihi batas ukuran
+//   - 415 untuk Content-Type yang tidak didukung
+//   - 422 untuk pelanggaran tag `validate`; Err berisi validation.Errors
+type BindError struct {
+	Status int
+	Source string // path, query, header, form atau body
+	Field  string // nama parameter; kosong untuk error body dan validasi
+	Err    error
+}
+
+func (e *BindError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("invalid %s parameter %q: %v", e.Source, e.Field, e.Err)
+	}
+	if e.Source != "" {
+		return fmt.Sprintf("invalid request %s: %v", e.Source, e.Err)
+	}
+	return e.Err.Error()
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+func (e *BindError) StatusCode() int {
+	return e.Status
+}
+
+type bindOptions struct {
+	maxBodySize           int64
+	disallowUnknownFields bool
+}
+
+type BindOption func(*bindOptions)
+
+// WithMaxBodySize mengganti batas ukuran body (default DefaultMaxBodySize)
+func WithMaxBodySize(n int64) BindOption {
+	return func(o *bindOptions) {
+		o.maxBodySize = n
+	}
+}
+
+// WithDisallowUnknownFields menolak field JSON yang tidak ada di struct. Tidak berlaku untuk XML.
+func WithDisallowUnknownFields() BindOption {
+	return func(o *bindOptions) {
+		o.disallowUnknownFields = true
+	}
+}
+
+// Bind membuat T dan mengisinya dari request, lihat BindInto
+func Bind[T any](r *http.Request, opts ...BindOption) (T, error) {
+	var v T
+	err := BindInto(r, &v, opts...)
+	return v, err
+}
+
+// BindInto mengisi dst (pointer ke struct) dengan urutan, yang belakangan menimpa yang sebelumnya:
+//
+//  1. body JSON atau XML sesuai Content-Type, memakai tag `json` / `xml`. Field dengan tag
+//     form, query, header atau path tanpa tag `json` / `xml` tidak pernah diisi dari body.
+//  2. body form (urlencoded atau multipart), tag `form:"name"`
+//  3. query string, tag `query:"name"`
+//  4. header, tag `header:"X-Name"`
+//  5. path parameter, tag `path:"name"`
+//
+// Setelah itu tag `validate` dijalankan. Semua error dikembalikan sebagai *BindError,
+// sehingga error handler router merendernya dengan status yang sesuai.
+func BindInto(r *http.Request, dst any, opts ...BindOption) error {
+	o := bindOptions{maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("httprouter: bind target must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	if err := bindBody(r, dst, o); err != nil {
+		return err
+	}
+
+	sources := []struct {
+		tag    string
+		values func(name string) []string
+	}{
+		{"form", func(name string) []string { return r.PostForm[name] }},
+		{"query", func(name string) []string { return r.URL.Query()[name] }},
+		{"header", func(name string) []string { return r.Header.Values(name) }},
+		{"path", func(name string) []string {
+			if value := r.PathValue(name); value != "" {
+				return []string{value}
+			}
+			return nil
+		}},
+	}
+	for _, src := range sources {
+		if err := bindValues(v.Elem(), src.tag, src.values); err != nil {
+			return err
+		}
+	}
+
+	if verrs := validation.ValidateStruct(dst); verrs.HasErrors() {
+		return &BindError{Status: http.StatusUnprocessableEntity, Err: verrs}
+	}
+	return nil
+}
+
+func bindBody(r *http.Request, dst any, o bindOptions) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, o.maxBodySize)
+
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	var err error
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		dec := json.NewDecoder(r.Body)
+		if o.disallowUnknownFields {
+			dec.DisallowUnknownFields()
+		}
+		restore := protectFields(reflect.ValueOf(dst).Elem(), "json")
+		err = dec.Decode(dst)
+		restore()
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		restore := protectFields(reflect.ValueOf(dst).Elem(), "xml")
+		err = xml.NewDecoder(r.Body).Decode(dst)
+		restore()
+	case mediaType == "application/x-www-form-urlencoded":
+		err = r.ParseForm()
+	case mediaType == "multipart/form-data":
+		err = r.ParseMultipartForm(o.maxBodySize)
+	default:
+		// Body kosong tanpa Content-Type (misalnya POST tanpa payload) tetap diterima
+		if contentType == "" && r.ContentLength == 0 {
+			return nil
+		}
+		return &BindError{
+			Status: http.StatusUnsupportedMediaType,
+			Source: "body",
+			Err:    fmt.Errorf("unsupported content type %q", contentType),
+		}
+	}
+
+	var maxErr *http.MaxBytesError
+	switch {
+	case err == nil, errors.Is(err, io.EOF):
+		return nil
+	case errors.As(err, &maxErr):
+		return &BindError{Status: http.StatusRequestEntityTooLarge, Source: "body", Err: err}
+	default:
+		return &BindError{Status: http.StatusBadRequest, Source: "body", Err: err}
+	}
+}
+
+// paramTags adalah tag sumber selain body
+var paramTags = []string{"form", "query", "header", "path"}
+
+// protectFields menyimpan nilai field yang punya tag form, query, header atau path tetapi tidak
+// punya bodyTag, lalu mengembalikan fungsi untuk memulihkannya setelah body di-decode.
+// Tanpa ini client bisa mengisi field seperti `header:"X-User-ID"` lewat body saat header-nya tidak dikirim.
+func protectFields(v reflect.Value, bodyTag string) (restore func()) {
+	type saved struct {
+		field, value reflect.Value
+	}
+	var fields []saved
+
+	var collect func(v reflect.Value)
+	collect = func(v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				collect(v.Field(i))
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			if _, ok := sf.Tag.Lookup(bodyTag); ok {
+				continue
+			}
+			for _, tag := range paramTags {
+				if _, ok := sf.Tag.Lookup(tag); ok {
+					value := reflect.New(sf.Type).Elem()
+					value.Set(v.Field(i))
+					fields = append(fields, saved{field: v.Field(i), value: value})
+					break
+				}
+			}
+		}
+	}
+	collect(v)
+
+	return func() {
+		for _, f := range fields {
+			f.field.Set(f.value)
+		}
+	}
+}
+
+// bindValues mengisi field yang punya tag dengan value dari values. Embedded struct diproses rekursif.
+func bindValues(v reflect.Value, tag string, values func(name string) []string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			if err := bindValues(v.Field(i), tag, values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := sf.Tag.Get(tag)
+		if !sf.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		raw := values(name)
+		if len(raw) == 0 {
+			continue
+		}
+		if err := setField(v.Field(i), raw); err != nil {
+			return &BindError{Status: http.StatusBadRequest, Source: tag, Field: name, Err: err}
+		}
+	}
+	return nil
+}
+
+// setField mengisi v dari raw. Slice diisi dari semua value, tipe lain dari value pertama.
+func setField(v reflect.Value, raw []string) error {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && !v.Addr().Type().Implements(textUnmarshalerType) {
+		slice := reflect.MakeSlice(v.Type(), len(raw), len(raw))
+		for i, s := range raw {
+			if err := setScalar(slice.Index(i), s); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setScalar(v, raw[0])
+}
+
+func setScalar(v reflect.Value, raw string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+
+	case reflect.Pointer:
+		elem := reflect.New(v.Type().Elem())
+		if err := setScalar(elem.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(elem)
+
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
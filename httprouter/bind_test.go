@@ -0,0 +1,157 @@
+package httprouter
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatkulnurk/foundation/validation"
+)
+
+type updateUserRequest struct {
+	ID        int           `path:"id"`
+	Name      string        `json:"name" xml:"name" form:"name" validate:"required"`
+	Email     string        `json:"email" xml:"email" form:"email"`
+	Tags      []string      `query:"tag"`
+	Notify    *bool         `query:"notify"`
+	Timeout   time.Duration `query:"timeout"`
+	RequestID string        `header:"X-Request-ID"`
+}
+
+func bindRequest(t *testing.T, method, target, contentType, body string, opts ...BindOption) (updateUserRequest, error) {
+	t.Helper()
+
+	var (
+		got updateUserRequest
+		err error
+	)
+	r := New()
+	r.Handle(method+" /users/{id}", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got, err = Bind[updateUserRequest](req, opts...)
+	}))
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body == "" {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("X-Request-ID", "req-1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	return got, err
+}
+
+func TestBind_Sources(t *testing.T) {
+	got, err := bindRequest(t, "PUT", "/users/42?tag=a&tag=b&notify=true&timeout=5s",
+		"application/json", `{"name":"Budi","email":"budi@example.com"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ID != 42 || got.Name != "Budi" || got.Email != "budi@example.com" || got.RequestID != "req-1" {
+		t.Errorf("unexpected result: %+v", got)
+	}
+	if len(got.Tags) != 2 || got.Tags[1] != "b" {
+		t.Errorf("expected tags [a b], got %v", got.Tags)
+	}
+	if got.Notify == nil || !*got.Notify || got.Timeout != 5*time.Second {
+		t.Errorf("unexpected notify/timeout: %v %v", got.Notify, got.Timeout)
+	}
+}
+
+func TestBind_BodyCannotSetParamFields(t *testing.T) {
+	tests := map[string]struct {
+		contentType, body string
+	}{
+		"json": {"application/json", `{"name":"Budi","ID":7,"Tags":["admin"],"Notify":true,"RequestID":"forged"}`},
+		"xml":  {"application/xml", `<user><name>Budi</name><ID>7</ID><Tags>admin</Tags><Notify>true</Notify></user>`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := bindRequest(t, "PUT", "/users/42", tt.contentType, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != "Budi" || got.ID != 42 || got.RequestID != "req-1" {
+				t.Errorf("unexpected result: %+v", got)
+			}
+			if got.Tags != nil || got.Notify != nil {
+				t.Errorf("query fields were set from the body: tags %v, notify %v", got.Tags, got.Notify)
+			}
+		})
+	}
+}
+
+func TestBind_FormAndXML(t *testing.T) {
+	got, err := bindRequest(t, "POST", "/users/1", "application/x-www-form-urlencoded", "name=Ani&email=ani%40example.com")
+	if err != nil || got.Name != "Ani" || got.Email != "ani@example.com" {
+		t.Errorf("form: unexpected result %+v, err %v", got, err)
+	}
+
+	got, err = bindRequest(t, "POST", "/users/1", "application/xml", "<user><name>Ani</name></user>")
+	if err != nil || got.Name != "Ani" {
+		t.Errorf("xml: unexpected result %+v, err %v", got, err)
+	}
+}
+
+func TestBind_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		contentType string
+		body        string
+		opts        []BindOption
+		status      int
+	}{
+		{"invalid path param", "/users/abc", "application/json", `{"name":"x"}`, nil, 400},
+		{"invalid query param", "/users/1?timeout=soon", "application/json", `{"name":"x"}`, nil, 400},
+		{"malformed json", "/users/1", "application/json", `{"name":`, nil, 400},
+		{"unknown field", "/users/1", "application/json", `{"name":"x","admin":true}`, []BindOption{WithDisallowUnknownFields()}, 400},
+		{"body too large", "/users/1", "application/json", `{"name":"xxxxxxxxxx"}`, []BindOption{WithMaxBodySize(8)}, 413},
+		{"unsupported media type", "/users/1", "text/csv", "name\nx", nil, 415},
+		{"validation", "/users/1", "application/json", `{"email":"x@example.com"}`, nil, 422},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bindRequest(t, "PUT", tt.target, tt.contentType, tt.body, tt.opts...)
+
+			var bindErr *BindError
+			if !errors.As(err, &bindErr) {
+				t.Fatalf("expected *BindError, got %v", err)
+			}
+			if StatusOf(err) != tt.status {
+				t.Errorf("expected status %d, got %d (%v)", tt.status, StatusOf(err), err)
+			}
+		})
+	}
+}
+
+func TestBind_RenderedByErrorHandler(t *testing.T) {
+	r := New()
+	r.POST("/users", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		if _, err := Bind[updateUserRequest](r); err != nil {
+			return err
+		}
+		return nil
+	}))
+
+	req := httptest.NewRequest("POST", "/users", strings.NewReader(`{"email":"x@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assertStatus(t, w.Code, http.StatusUnprocessableEntity)
+	assertContains(t, w.Body.String(), `"errors":[{"field":"name"`)
+
+	var verrs validation.Errors
+	_, err := bindRequest(t, "PUT", "/users/1", "application/json", `{}`)
+	if !errors.As(err, &verrs) || len(verrs.ForField("name")) != 1 {
+		t.Errorf("expected validation error for name, got %v", err)
+	}
+}
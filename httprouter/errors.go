@@ -0,0 +1,81 @@
+package httprouter
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+
+	"github.com/fatkulnurk/foundation/validation"
+)
+
+// ErrNotFound bisa dikembalikan handler (atau dibungkus) supaya dirender sebagai 404
+var ErrNotFound = NewHTTPError(http.StatusNotFound, "resource not found")
+
+// StatusCoder diimplementasikan error yang membawa HTTP status code sendiri
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// HTTPError adalah error dengan HTTP status. Message ditampilkan ke client,
+// sedangkan Err (opsional) hanya untuk log dan errors.Is / errors.As.
+type HTTPError struct {
+	Status  int
+	Message string
+	Err     error
+}
+
+// NewHTTPError membuat HTTPError; message kosong berarti http.StatusText(status)
+func NewHTTPError(status int, message string) *HTTPError {
+	return &HTTPError{Status: status, Message: message}
+}
+
+// WrapHTTPError membungkus err dengan status dan message untuk client
+func WrapHTTPError(status int, message string, err error) *HTTPError {
+	return &HTTPError{Status: status, Message: message, Err: err}
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%d %s: %v", e.Status, e.message(), e.Err)
+	}
+	return fmt.Sprintf("%d %s", e.Status, e.message())
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+func (e *HTTPError) StatusCode() int {
+	return e.Status
+}
+
+func (e *HTTPError) message() string {
+	if e.Message == "" {
+		return http.StatusText(e.Status)
+	}
+	return e.Message
+}
+
+// StatusOf menentukan HTTP status untuk err:
+//   - error yang mengimplementasikan StatusCoder → status miliknya
+//   - validation.Errors → 422
+//   - ErrNotFound atau fs.ErrNotExist → 404
+//   - selain itu → 500
+func StatusOf(err error) int {
+	var sc StatusCoder
+	if errors.As(err, &sc) {
+		return sc.StatusCode()
+	}
+
+	var verrs validation.Errors
+	if errors.As(err, &verrs) {
+		return http.StatusUnprocessableEntity
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
@@ -2,4 +2,15 @@ module github.com/fatkulnurk/foundation/httprouter
 
 go 1.25
 
-require go.uber.org/mock v0.6.0 // indirect
+require (
+	github.com/fatkulnurk/foundation/validation v0.0.0-00010101000000-000000000000
+	github.com/fatkulnurk/foundation/view v0.0.0-00010101000000-000000000000
+	go.uber.org/mock v0.6.0
+)
+
+require github.com/google/uuid v1.6.0 // indirect
+
+replace (
+	github.com/fatkulnurk/foundation/validation => ../validation
+	github.com/fatkulnurk/foundation/view => ../view
+)
@@ -0,0 +1,107 @@
+package httprouter
+
+import (
+	"encoding/xml"
+	"html"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fatkulnurk/foundation/validation"
+)
+
+// HandlerFunc adalah handler yang mengembalikan error. Error dirender oleh ErrorHandler router,
+// jadi handler tidak perlu menulis response error sendiri.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ErrorHandler merender error yang dikembalikan HandlerFunc
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// SetErrorHandler mengganti ErrorHandler untuk semua route di router ini, termasuk group.
+// Panggil saat setup, sebelum server menerima request.
+func (r *Router) SetErrorHandler(h ErrorHandler) {
+	r.errorHandler = h
+}
+
+// E mengubah HandlerFunc menjadi http.HandlerFunc yang error-nya dirender ErrorHandler router ini,
+// supaya bisa dipakai dengan GET, POST, dan seterusnya, termasuk di dalam Group:
+//
+//	r.GET("/users/{id}", r.E(showUser))
+func (r *Router) E(h HandlerFunc) http.HandlerFunc {
+	return r.withErrorHandler(h)
+}
+
+func (r *Router) withErrorHandler(h HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		err := h(w, req)
+		if err == nil {
+			return
+		}
+
+		// Dibaca saat request, supaya SetErrorHandler setelah route didaftarkan tetap berlaku
+		if r != nil && r.errorHandler != nil {
+			r.errorHandler(w, req, err)
+			return
+		}
+		DefaultErrorHandler(w, req, err)
+	}
+}
+
+// errorBody adalah bentuk XML dari Problem
+type errorBody struct {
+	XMLName xml.Name           `xml:"error"`
+	Status  int                `xml:"status"`
+	Title   string             `xml:"title"`
+	Detail  string             `xml:"detail,omitempty"`
+	Errors  []validation.Error `xml:"errors>error,omitempty"`
+}
+
+// DefaultErrorHandler merender err sebagai Problem (lihat ProblemOf), dalam format yang diminta
+// header Accept (dengan q-value, lihat NegotiateContentType): problem+json, XML atau HTML;
+// default problem+json. Pesan error 5xx yang bukan
+// HTTPError tidak dikirim ke client, hanya dicatat ke log.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	p := ProblemOf(err)
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+
+	if p.Status >= http.StatusInternalServerError {
+		log.Printf("[ERROR] %s %s: %v", r.Method, r.URL.Path, err)
+	}
+
+	w.Header().Add("Vary", "Accept")
+
+	// Accept yang tidak cocok dengan offer mana pun tetap mendapat problem+json:
+	// error lebih berguna daripada 406.
+	offers := []string{ProblemContentType, "application/json", "text/html", "application/xml"}
+	switch NegotiateContentType(r.Header.Get("Accept"), offers...) {
+	case "application/xml":
+		verrs, _ := p.Extensions["errors"].(validation.Errors)
+		WriteXML(w, p.Status, errorBody{Status: p.Status, Title: p.Title, Detail: p.Detail, Errors: verrs})
+	case "text/html":
+		WriteHTML(w, p.Status, errorHTML(p))
+	default:
+		WriteProblem(w, p)
+	}
+}
+
+func errorHTML(p *Problem) string {
+	var b strings.Builder
+	title := html.EscapeString(strconv.Itoa(p.Status) + " " + p.Title)
+	b.WriteString("<!DOCTYPE html><html><head><title>" + title + "</title></head><body>")
+	b.WriteString("<h1>" + title + "</h1>")
+	if p.Detail != "" {
+		b.WriteString("<p>" + html.EscapeString(p.Detail) + "</p>")
+	}
+	if verrs, ok := p.Extensions["errors"].(validation.Errors); ok {
+		b.WriteString("<ul>")
+		for _, e := range verrs {
+			b.WriteString("<li>" + html.EscapeString(e.Field+": "+e.Message) + "</li>")
+		}
+		b.WriteString("</ul>")
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
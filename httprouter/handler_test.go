@@ -0,0 +1,97 @@
+package httprouter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fatkulnurk/foundation/validation"
+)
+
+func TestRouter_ErrorHandlerFunc(t *testing.T) {
+	r := New()
+	r.GET("/ok", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		_, err := w.Write([]byte("ok"))
+		return err
+	}))
+	r.Group("/api", func(g HttpRouter) {
+		g.POST("/users", r.E(func(w http.ResponseWriter, r *http.Request) error {
+			return validation.Errors{{Field: "email", Message: "is not a valid email"}}
+		}))
+		g.GET("/users/{id}", r.E(func(w http.ResponseWriter, r *http.Request) error {
+			return fmt.Errorf("load user %s: %w", r.PathValue("id"), ErrNotFound)
+		}))
+		g.DELETE("/users/{id}", r.E(func(w http.ResponseWriter, r *http.Request) error {
+			return NewHTTPError(http.StatusForbidden, "not allowed")
+		}))
+		g.PUT("/users/{id}", r.E(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.New("database password is hunter2")
+		}))
+	})
+
+	w := makeRequest(t, r, "GET", "/ok", nil)
+	assertStatus(t, w.Code, http.StatusOK)
+	assertBody(t, w.Body.String(), "ok")
+
+	w = makeRequest(t, r, "POST", "/api/users", nil)
+	assertStatus(t, w.Code, http.StatusUnprocessableEntity)
+	assertBody(t, w.Header().Get("Content-Type"), ProblemContentType)
+	assertContains(t, w.Body.String(), `"errors":[{"field":"email","message":"is not a valid email"}]`)
+
+	w = makeRequest(t, r, "GET", "/api/users/42", map[string]string{"Accept": "application/xml"})
+	assertStatus(t, w.Code, http.StatusNotFound)
+	assertContains(t, w.Body.String(), "<error><status>404</status>")
+
+	w = makeRequest(t, r, "DELETE", "/api/users/42", map[string]string{"Accept": "text/html"})
+	assertStatus(t, w.Code, http.StatusForbidden)
+	assertContains(t, w.Body.String(), "<p>not allowed</p>")
+
+	w = makeRequest(t, r, "PUT", "/api/users/42", nil)
+	assertStatus(t, w.Code, http.StatusInternalServerError)
+	if strings.Contains(w.Body.String(), "hunter2") {
+		t.Errorf("internal error leaked to client: %s", w.Body.String())
+	}
+}
+
+func TestRouter_SetErrorHandler(t *testing.T) {
+	r := New()
+	r.GET("/fail", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		return NewHTTPError(http.StatusTeapot, "")
+	}))
+	r.SetErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+		ResponseOf(w).Status(StatusOf(err)).Text("custom: " + err.Error())
+	})
+
+	w := makeRequest(t, r, "GET", "/fail", nil)
+	assertStatus(t, w.Code, http.StatusTeapot)
+	assertBody(t, w.Body.String(), "custom: 418 I'm a teapot")
+}
+
+func TestDefaultErrorHandler_NegotiatesAccept(t *testing.T) {
+	r := New()
+	r.GET("/missing", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		return ErrNotFound
+	}))
+
+	tests := []struct {
+		accept      string
+		contentType string
+	}{
+		{"", ProblemContentType},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "text/html"},
+		{"application/json", ProblemContentType},
+		{"application/xml", "application/xml"},
+		{"application/json;q=0, application/xml", "application/xml"},
+		{"image/png", ProblemContentType},
+	}
+
+	for _, tt := range tests {
+		w := makeRequest(t, r, "GET", "/missing", map[string]string{"Accept": tt.accept})
+		assertStatus(t, w.Code, http.StatusNotFound)
+		if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.contentType) {
+			t.Errorf("Accept %q: expected Content-Type %s, got %s", tt.accept, tt.contentType, got)
+		}
+	}
+}
@@ -201,6 +201,26 @@ func (mr *MockHttpRouterMockRecorder) Static(prefix, dir any, mws ...any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Static", reflect.TypeOf((*MockHttpRouter)(nil).Static), varargs...)
 }
 
+// URL mocks base method.
+func (m *MockHttpRouter) URL(name string, params ...any) (string, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{name}
+	for _, a := range params {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "URL", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockHttpRouterMockRecorder) URL(name any, params ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{name}, params...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockHttpRouter)(nil).URL), varargs...)
+}
+
 // Use mocks base method.
 func (m *MockHttpRouter) Use(mw func(http.Handler) http.Handler) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,134 @@
+package httprouter
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fatkulnurk/foundation/view"
+)
+
+// ErrNotAcceptable dikembalikan Negotiate saat tidak ada format yang diterima client
+var ErrNotAcceptable = NewHTTPError(http.StatusNotAcceptable, "none of the available representations is acceptable")
+
+// Template() → set template yang dipakai Negotiate untuk text/html (chainable).
+// Tanpa Template, Negotiate tidak menawarkan HTML.
+func (r *Response) Template(v view.View, name string, layout ...string) *Response {
+	r.view = v
+	r.template = name
+	if len(layout) > 0 {
+		r.layout = layout[0]
+	}
+	return r
+}
+
+// Negotiate() → kirim data dalam format terbaik menurut header Accept (dengan q-value):
+// JSON, XML, plain text, atau HTML jika Template di-set. Accept kosong atau */* berarti JSON.
+// Jika tidak ada yang cocok, tidak ada yang ditulis dan ErrNotAcceptable dikembalikan,
+// sehingga handler bisa langsung mengembalikannya ke error handler router.
+func (r *Response) Negotiate(req *http.Request, data any) error {
+	offers := []string{"application/json", "application/xml", "text/plain"}
+	if r.view != nil {
+		offers = append(offers, "text/html")
+	}
+
+	r.headers.Add("Vary", "Accept")
+
+	switch NegotiateContentType(req.Header.Get("Accept"), offers...) {
+	case "application/json":
+		r.JSON(data)
+	case "application/xml":
+		r.XML(data)
+	case "text/plain":
+		r.Text(fmt.Sprint(data))
+	case "text/html":
+		html, err := r.view.RenderWithLayout(req.Context(), r.layout, r.template, data)
+		if err != nil {
+			return err
+		}
+		r.HTML(html)
+	default:
+		return ErrNotAcceptable
+	}
+	return nil
+}
+
+// NegotiateContentType memilih offer dengan q-value tertinggi dari header Accept.
+// Q-value sebuah offer diambil dari media range paling spesifik yang cocok
+// (text/html > text/* > */*). Jika q sama, offer yang lebih dulu menang.
+// Mengembalikan "" jika tidak ada offer yang bisa diterima.
+func NegotiateContentType(accept string, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+		for _, ar := range ranges {
+			if s := ar.match(offer); s > specificity {
+				q, specificity = ar.q, s
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+type acceptRange struct {
+	typ, subtype string
+	q            float64
+}
+
+// match mengembalikan tingkat spesifik kecocokan (2 = persis, 1 = type/*, 0 = */*), atau -1
+func (ar acceptRange) match(offer string) int {
+	typ, subtype, _ := strings.Cut(offer, "/")
+	switch {
+	case ar.typ == typ && ar.subtype == subtype:
+		return 2
+	case ar.typ == typ && ar.subtype == "*":
+		return 1
+	case ar.typ == "*" && ar.subtype == "*":
+		return 0
+	default:
+		return -1
+	}
+}
+
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		// Beberapa client mengirim "*" untuk */*
+		if mediaType == "*" {
+			mediaType = "*/*"
+		}
+
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, acceptRange{typ: typ, subtype: subtype, q: q})
+	}
+	return ranges
+}
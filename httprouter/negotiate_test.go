@@ -0,0 +1,93 @@
+package httprouter
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fatkulnurk/foundation/view/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{"application/json", "application/xml", "text/plain", "text/html"}
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", "application/json"},
+		{"*/*", "application/json"},
+		{"application/xml", "application/xml"},
+		{"text/*", "text/plain"},
+		{"text/plain;q=0.5, text/html", "text/html"},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "text/html"},
+		{"application/json;q=0.2, */*;q=0.5", "application/xml"},
+		{"*/*, application/json;q=0", "application/xml"},
+		{"image/png", ""},
+		{"application/json;q=abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if got := NegotiateContentType(tt.accept, offers...); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResponse_Negotiate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	v := mocks.NewMockView(ctrl)
+	v.EXPECT().RenderWithLayout(gomock.Any(), "app", "users/show", gomock.Any()).Return("<h1>Budi</h1>", nil)
+
+	type user struct {
+		Name string `json:"name" xml:"name"`
+	}
+	data := user{Name: "Budi"}
+
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"application/json", "application/json; charset=utf-8", `{"name":"Budi"}` + "\n"},
+		{"application/xml", "application/xml; charset=utf-8", "<user><name>Budi</name></user>"},
+		{"text/plain", "text/plain; charset=utf-8", "{Budi}"},
+		{"text/html", "text/html; charset=utf-8", "<h1>Budi</h1>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/users/1", nil)
+			req.Header.Set("Accept", tt.accept)
+
+			if err := ResponseOf(w).Template(v, "users/show", "app").Negotiate(req, data); err != nil {
+				t.Fatal(err)
+			}
+			assertStatus(t, w.Code, http.StatusOK)
+			assertBody(t, w.Header().Get("Content-Type"), tt.contentType)
+			assertBody(t, w.Header().Get("Vary"), "Accept")
+			assertBody(t, w.Body.String(), tt.body)
+		})
+	}
+}
+
+func TestResponse_NegotiateNotAcceptable(t *testing.T) {
+	r := New()
+	r.GET("/users/1", r.E(func(w http.ResponseWriter, r *http.Request) error {
+		// Tanpa Template, HTML tidak ditawarkan
+		return ResponseOf(w).Negotiate(r, map[string]string{"name": "Budi"})
+	}))
+
+	w := makeRequest(t, r, "GET", "/users/1", map[string]string{"Accept": "text/html"})
+	assertStatus(t, w.Code, http.StatusNotAcceptable)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept", "image/png")
+	if err := ResponseOf(httptest.NewRecorder()).Negotiate(req, nil); !errors.Is(err, ErrNotAcceptable) {
+		t.Errorf("expected ErrNotAcceptable, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package httprouter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+
+	"github.com/fatkulnurk/foundation/validation"
+)
+
+// ProblemContentType adalah media type untuk Problem (RFC 9457)
+const ProblemContentType = "application/problem+json"
+
+// Problem adalah response error dengan format RFC 9457 (application/problem+json).
+// Extensions berisi member tambahan yang ditulis sejajar dengan member standar.
+type Problem struct {
+	Type       string // URI jenis problem; kosong berarti "about:blank"
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+// problemMembers adalah nama member standar yang tidak boleh ditimpa Extensions
+var problemMembers = map[string]bool{"type": true, "title": true, "status": true, "detail": true, "instance": true}
+
+// NewProblem membuat Problem dengan title dari http.StatusText(status)
+func NewProblem(status int, detail string) *Problem {
+	return &Problem{Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+// ValidationProblem membuat Problem 422 dengan pelanggaran validasi di extension "errors"
+func ValidationProblem(errs validation.Errors) *Problem {
+	return NewProblem(http.StatusUnprocessableEntity, "request validation failed").With("errors", errs)
+}
+
+// ProblemOf mengubah err menjadi Problem dengan status dari StatusOf.
+// validation.Errors menjadi extension "errors", Problem di dalam err disalin,
+// dan pesan error 5xx yang bukan HTTPError tidak dimasukkan ke Detail.
+// Hasilnya selalu Problem baru, jadi aman diubah meskipun err adalah sentinel yang dipakai bersama.
+func ProblemOf(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		cp := *p
+		cp.Extensions = maps.Clone(p.Extensions)
+		return &cp
+	}
+
+	var verrs validation.Errors
+	if errors.As(err, &verrs) {
+		return ValidationProblem(verrs)
+	}
+
+	status := StatusOf(err)
+	p = NewProblem(status, "")
+
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		p.Detail = httpErr.message()
+	case status < http.StatusInternalServerError:
+		p.Detail = err.Error()
+	}
+	return p
+}
+
+// With menambahkan extension member (chainable). Nama member standar diabaikan saat encode.
+func (p *Problem) With(key string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+// Problem juga bisa dikembalikan langsung dari HandlerFunc
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return fmt.Sprintf("%d %s: %s", p.Status, p.Title, p.Detail)
+	}
+	return fmt.Sprintf("%d %s", p.Status, p.Title)
+}
+
+func (p *Problem) StatusCode() int {
+	return p.Status
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		if !problemMembers[k] {
+			m[k] = v
+		}
+	}
+
+	m["type"] = p.Type
+	if p.Type == "" {
+		m["type"] = "about:blank"
+	}
+	m["title"] = p.Title
+	if p.Title == "" {
+		m["title"] = http.StatusText(p.Status)
+	}
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON membaca problem+json, misalnya dari response service lain.
+// Member selain member standar masuk ke Extensions sebagai json.RawMessage.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	*p = Problem{}
+	for k, raw := range m {
+		var err error
+		switch k {
+		case "type":
+			err = json.Unmarshal(raw, &p.Type)
+		case "title":
+			err = json.Unmarshal(raw, &p.Title)
+		case "status":
+			err = json.Unmarshal(raw, &p.Status)
+		case "detail":
+			err = json.Unmarshal(raw, &p.Detail)
+		case "instance":
+			err = json.Unmarshal(raw, &p.Instance)
+		default:
+			p.With(k, raw)
+		}
+		if err != nil {
+			return fmt.Errorf("problem member %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// Problem() → kirim Problem sebagai application/problem+json.
+// Status response diambil dari p.Status; jika 0, dipakai status dari Status().
+func (r *Response) Problem(p *Problem) {
+	if p.Status != 0 {
+		r.statusCode = p.Status
+	}
+	r.writeHeaders(ProblemContentType)
+	enc := json.NewEncoder(r.w)
+	enc.SetEscapeHTML(true)
+	_ = enc.Encode(p)
+}
+
+// ProblemError() → kirim err sebagai Problem, lihat ProblemOf
+func (r *Response) ProblemError(err error) {
+	r.Problem(ProblemOf(err))
+}
+
+// WriteProblem mengirim Problem sebagai application/problem+json
+func WriteProblem(w http.ResponseWriter, p *Problem) {
+	ResponseOf(w).Problem(p)
+}
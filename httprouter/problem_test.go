@@ -0,0 +1,114 @@
+package httprouter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fatkulnurk/foundation/validation"
+)
+
+func TestResponse_Problem(t *testing.T) {
+	w := httptest.NewRecorder()
+	p := NewProblem(http.StatusConflict, "email already registered").With("email", "a@b.c").With("status", 200)
+	p.Type = "https://example.com/problems/duplicate"
+	p.Instance = "/users"
+	ResponseOf(w).Problem(p)
+
+	assertStatus(t, w.Code, http.StatusConflict)
+	if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("expected content type %q, got %q", ProblemContentType, ct)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"type":     "https://example.com/problems/duplicate",
+		"title":    "Conflict",
+		"status":   float64(409),
+		"detail":   "email already registered",
+		"instance": "/users",
+		"email":    "a@b.c",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("member %q: expected %v, got %v", k, v, got[k])
+		}
+	}
+}
+
+func TestProblemOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		detail string
+	}{
+		{"plain error", errors.New("db down"), 500, ""},
+		{"http error", NewHTTPError(http.StatusForbidden, "not allowed"), 403, "not allowed"},
+		{"problem", NewProblem(http.StatusTeapot, "short and stout"), 418, "short and stout"},
+		{"validation", validation.Errors{{Field: "name", Message: "is required"}}, 422, "request validation failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ProblemOf(tt.err)
+			if p.Status != tt.status || p.Detail != tt.detail {
+				t.Errorf("expected %d %q, got %d %q", tt.status, tt.detail, p.Status, p.Detail)
+			}
+		})
+	}
+}
+
+func TestProblem_RoundTrip(t *testing.T) {
+	data, err := json.Marshal(ValidationProblem(validation.Errors{{Field: "name", Message: "is required"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, string(data), `"type":"about:blank"`)
+
+	var p Problem
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != 422 || p.Title != "Unprocessable Entity" {
+		t.Errorf("unexpected problem: %+v", p)
+	}
+
+	var verrs validation.Errors
+	if err := json.Unmarshal(p.Extensions["errors"].(json.RawMessage), &verrs); err != nil {
+		t.Fatal(err)
+	}
+	if len(verrs) != 1 || verrs[0].Field != "name" {
+		t.Errorf("unexpected errors extension: %v", verrs)
+	}
+}
+
+func TestDefaultErrorHandler_SharedProblem(t *testing.T) {
+	errQuota := NewProblem(http.StatusTooManyRequests, "quota exceeded").With("limit", 100)
+
+	r := New()
+	r.GET("/a", r.E(func(w http.ResponseWriter, r *http.Request) error { return errQuota }))
+	r.GET("/b", r.E(func(w http.ResponseWriter, r *http.Request) error { return errQuota }))
+
+	for _, path := range []string{"/a", "/b"} {
+		w := makeRequest(t, r, "GET", path, nil)
+		assertStatus(t, w.Code, http.StatusTooManyRequests)
+
+		var got map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got["instance"] != path || got["limit"] != float64(100) {
+			t.Errorf("%s: unexpected problem %v", path, got)
+		}
+	}
+
+	if errQuota.Instance != "" {
+		t.Errorf("shared problem was mutated: instance %q", errQuota.Instance)
+	}
+}
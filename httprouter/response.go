@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"net/http"
+
+	"github.com/fatkulnurk/foundation/view"
 )
 
 type Response struct {
@@ -11,6 +13,11 @@ type Response struct {
 	statusCode int
 	headers    http.Header
 	wrote      bool
+
+	// dipakai Negotiate untuk text/html, lihat Template
+	view     view.View
+	template string
+	layout   string
 }
 
 // response(w) → *Response
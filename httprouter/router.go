@@ -23,13 +23,18 @@ type HttpRouter interface {
 
 	Group(prefix string, fn func(g HttpRouter))
 	Static(prefix string, dir string, mws ...func(http.Handler) http.Handler)
+
+	// URL membuat path dari route yang diberi nama dengan Named
+	URL(name string, params ...any) (string, error)
 }
 
 // =============== IMPLEMENTASI ===============
 
 type Router struct {
-	mux         *http.ServeMux
-	middlewares []func(http.Handler) http.Handler
+	mux          *http.ServeMux
+	middlewares  []func(http.Handler) http.Handler
+	errorHandler ErrorHandler
+	routes       map[string]string // nama route → path, lihat Named
 }
 
 type Group struct {
@@ -42,6 +47,7 @@ func New() *Router {
 	return &Router{
 		mux:         http.NewServeMux(),
 		middlewares: nil,
+		routes:      make(map[string]string),
 	}
 }
 
@@ -61,6 +67,8 @@ func chain(h http.Handler, mws []func(http.Handler) http.Handler) http.Handler {
 
 // Handle: pattern full, contoh: "GET /users/{id}"
 func (r *Router) Handle(pattern string, h http.Handler, mws ...func(http.Handler) http.Handler) {
+	h = r.register(pattern, h)
+
 	all := append(append([]func(http.Handler) http.Handler{}, r.middlewares...), mws...)
 	final := chain(h, all)
 
@@ -158,6 +166,8 @@ func (g *Group) Handle(pattern string, h http.Handler, mws ...func(http.Handler)
 		fullPattern = join(g.prefix, pattern)
 	}
 
+	h = g.router.register(fullPattern, h)
+
 	// global router → group → route
 	all := append([]func(http.Handler) http.Handler{}, g.router.middlewares...)
 	all = append(all, g.middlewares...)
@@ -195,6 +205,10 @@ func (g *Group) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	g.router.ServeHTTP(w, req)
 }
 
+func (g *Group) URL(name string, params ...any) (string, error) {
+	return g.router.URL(name, params...)
+}
+
 func (g *Group) Group(prefix string, fn func(g HttpRouter)) {
 	newGroup := &Group{
 		router: g.router,
@@ -0,0 +1,151 @@
+package httprouter
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrRouteNotFound dikembalikan URL saat nama route belum didaftarkan
+var ErrRouteNotFound = errors.New("httprouter: route not found")
+
+// namedRoute membawa nama route sampai Handle, di mana pattern lengkapnya (termasuk prefix group) diketahui
+type namedRoute struct {
+	name    string
+	handler http.HandlerFunc
+}
+
+// Named memberi nama pada route yang didaftarkan dengan Handle, supaya URL-nya bisa dibuat dengan Router.URL:
+//
+//	g.Handle("GET /users/{id}", httprouter.Named("users.show", showUser))
+//	g.Handle("GET /users/{id}/edit", httprouter.Named("users.edit", r.E(editUser)))
+//
+// Nama yang sama didaftarkan dua kali menyebabkan panic.
+func Named(name string, h http.HandlerFunc) http.Handler {
+	return &namedRoute{name: name, handler: h}
+}
+
+func (n *namedRoute) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Normalnya tidak dipanggil karena Handle membuka wrapper ini saat route didaftarkan
+	n.handler(w, req)
+}
+
+// register menyimpan nama route dan mengembalikan handler aslinya
+func (r *Router) register(pattern string, h http.Handler) http.Handler {
+	n, ok := h.(*namedRoute)
+	if !ok {
+		return h
+	}
+
+	// "GET /users/{id}" → "/users/{id}"
+	path := pattern
+	if idx := strings.Index(pattern, "/"); idx >= 0 {
+		path = pattern[idx:]
+	}
+
+	if r.routes == nil {
+		r.routes = make(map[string]string)
+	}
+	if _, exists := r.routes[n.name]; exists {
+		panic(fmt.Sprintf("httprouter: duplicate route name %q", n.name))
+	}
+	r.routes[n.name] = path
+
+	return n.handler
+}
+
+// URL membuat path dari route bernama. params berisi pasangan key dan value:
+//
+//	router.URL("users.show", "id", 42)          // /users/42
+//	router.URL("users.index", "page", 2)        // /users?page=2
+//
+// Key yang cocok dengan wildcard {key} atau {key...} mengisi path (di-escape);
+// sisanya menjadi query string. Wildcard yang tidak diisi menghasilkan error.
+func (r *Router) URL(name string, params ...any) (string, error) {
+	path, ok := r.routes[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrRouteNotFound, name)
+	}
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("httprouter: route %q: params must be key-value pairs", name)
+	}
+
+	values := make(map[string]string, len(params)/2)
+	var keys []string
+	for i := 0; i < len(params); i += 2 {
+		key, ok := params[i].(string)
+		if !ok {
+			return "", fmt.Errorf("httprouter: route %q: param key %v is not a string", name, params[i])
+		}
+		if _, exists := values[key]; !exists {
+			keys = append(keys, key)
+		}
+		values[key] = fmt.Sprint(params[i+1])
+	}
+
+	var b strings.Builder
+	used := make(map[string]bool)
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			b.WriteString(path)
+			break
+		}
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			b.WriteString(path)
+			break
+		}
+		end += start
+
+		b.WriteString(path[:start])
+		wildcard := path[start+1 : end]
+		path = path[end+1:]
+
+		// {$} hanya penanda exact match
+		if wildcard == "$" {
+			continue
+		}
+
+		key, rest := strings.CutSuffix(wildcard, "...")
+		value, ok := values[key]
+		if !ok {
+			return "", fmt.Errorf("httprouter: route %q: missing param %q", name, key)
+		}
+		used[key] = true
+
+		if rest {
+			// {path...} boleh berisi "/", jadi setiap segmen di-escape sendiri
+			segments := strings.Split(value, "/")
+			for i, s := range segments {
+				segments[i] = url.PathEscape(s)
+			}
+			b.WriteString(strings.Join(segments, "/"))
+		} else {
+			b.WriteString(url.PathEscape(value))
+		}
+	}
+
+	query := url.Values{}
+	for _, key := range keys {
+		if !used[key] {
+			query.Set(key, values[key])
+		}
+	}
+	if len(query) > 0 {
+		b.WriteString("?" + query.Encode())
+	}
+
+	return b.String(), nil
+}
+
+// MustURL seperti URL tetapi panic jika gagal, untuk route yang pasti ada
+func (r *Router) MustURL(name string, params ...any) string {
+	u, err := r.URL(name, params...)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
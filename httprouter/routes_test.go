@@ -0,0 +1,99 @@
+package httprouter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatkulnurk/foundation/view"
+)
+
+func newNamedRouter(t *testing.T) *Router {
+	t.Helper()
+
+	r := New()
+	r.Handle("GET /{$}", Named("home", func(w http.ResponseWriter, r *http.Request) {}))
+	r.Group("/api", func(g HttpRouter) {
+		g.Group("/users", func(g HttpRouter) {
+			g.Handle("GET /", Named("users.index", func(w http.ResponseWriter, r *http.Request) {}))
+			g.Handle("GET /{id}", Named("users.show", r.E(func(w http.ResponseWriter, r *http.Request) error {
+				_, err := w.Write([]byte("user " + r.PathValue("id")))
+				return err
+			})))
+		})
+	})
+	r.Handle("GET /files/{path...}", Named("files", http.NotFound))
+	return r
+}
+
+func TestRouter_URL(t *testing.T) {
+	r := newNamedRouter(t)
+
+	tests := []struct {
+		name   string
+		params []any
+		want   string
+	}{
+		{"home", nil, "/"},
+		{"users.index", []any{"page", 2, "sort", "name"}, "/api/users?page=2&sort=name"},
+		{"users.show", []any{"id", 42}, "/api/users/42"},
+		{"users.show", []any{"id", "a b/c"}, "/api/users/a%20b%2Fc"},
+		{"files", []any{"path", "docs/read me.txt"}, "/files/docs/read%20me.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := r.URL(tt.name, tt.params...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertBody(t, got, tt.want)
+		})
+	}
+
+	// Named tetap melayani request seperti handler biasa
+	w := makeRequest(t, r, "GET", r.MustURL("users.show", "id", 7), nil)
+	assertStatus(t, w.Code, http.StatusOK)
+	assertBody(t, w.Body.String(), "user 7")
+}
+
+func TestRouter_URLErrors(t *testing.T) {
+	r := newNamedRouter(t)
+
+	if _, err := r.URL("users.edit"); !errors.Is(err, ErrRouteNotFound) {
+		t.Errorf("expected ErrRouteNotFound, got %v", err)
+	}
+	if _, err := r.URL("users.show"); err == nil {
+		t.Error("expected error for missing param")
+	}
+	if _, err := r.URL("users.show", "id"); err == nil {
+		t.Error("expected error for odd params")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for duplicate route name")
+		}
+	}()
+	r.Handle("POST /users", Named("users.show", func(w http.ResponseWriter, r *http.Request) {}))
+}
+
+func TestRouter_URLInTemplate(t *testing.T) {
+	dir := t.TempDir()
+	tpl := `{{ define "user" }}<a href="{{ url "users.show" "id" .ID }}">{{ .Name }}</a>{{ end }}`
+	if err := os.WriteFile(filepath.Join(dir, "user.html"), []byte(tpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := newNamedRouter(t)
+	v := view.New(view.Config{ViewsPath: dir, URLFunc: r.URL})
+
+	html, err := v.Render(context.Background(), "user", map[string]any{"ID": 42, "Name": "Budi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertBody(t, html, `<a href="/api/users/42">Budi</a>`)
+}
@@ -16,7 +16,7 @@ func NewSmtp(cfg *SMTPConfig) (*mail.Client, error) {
 		mail.WithSMTPAuth(mail.SMTPAuthType(cfg.AuthType)),
 		mail.WithTLSPortPolicy(mail.TLSPolicy(cfg.WithTLSPortPolicy)),
 		mail.WithUsername(cfg.Username),
-		mail.WithPassword(cfg.Password),
+		mail.WithPassword(cfg.Password.Reveal()),
 		mail.WithPort(cfg.Port),
 	)
 
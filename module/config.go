@@ -0,0 +1,53 @@
+package module
+
+import (
+	"strings"
+
+	"github.com/fatkulnurk/foundation/support"
+)
+
+type Config struct {
+	// Enabled berisi nama module yang dijalankan. Kosong = semua module.
+	Enabled []string
+
+	// Disabled berisi nama module yang tidak dijalankan, walaupun ada di Enabled
+	Disabled []string
+}
+
+func LoadConfig() *Config {
+	return &Config{
+		Enabled:  splitList(support.GetEnv("MODULES_ENABLED", "")),  // example: users,billing
+		Disabled: splitList(support.GetEnv("MODULES_DISABLED", "")), // example: reports
+	}
+}
+
+// IsEnabled mengecek apakah module dengan nama name boleh dijalankan
+func (c *Config) IsEnabled(name string) bool {
+	if c == nil {
+		return true
+	}
+	for _, disabled := range c.Disabled {
+		if disabled == name {
+			return false
+		}
+	}
+	if len(c.Enabled) == 0 {
+		return true
+	}
+	for _, enabled := range c.Enabled {
+		if enabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
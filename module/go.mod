@@ -1,3 +1,7 @@
 module github.com/fatkulnurk/foundation/module
 
 go 1.25
+
+require github.com/fatkulnurk/foundation/support v0.0.0-00010101000000-000000000000
+
+replace github.com/fatkulnurk/foundation/support => ../support
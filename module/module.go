@@ -1,5 +1,36 @@
 package module
 
+import "context"
+
 type Module interface {
 	Register()
 }
+
+// Named diimplementasikan module yang punya nama, dipakai oleh DependsOn dan Config.
+// Module tanpa Name memakai nama tipenya, misalnya "*billing.Module".
+type Named interface {
+	Name() string
+}
+
+// Dependent diimplementasikan module yang harus di-register setelah module lain
+type Dependent interface {
+	// DependsOn mengembalikan nama module yang dibutuhkan
+	DependsOn() []string
+}
+
+// Booter diimplementasikan module yang perlu inisialisasi setelah semua module ter-register,
+// misalnya membuka koneksi atau menjalankan migrasi
+type Booter interface {
+	Boot(ctx context.Context) error
+}
+
+// Starter diimplementasikan module yang perlu menjalankan sesuatu saat aplikasi start.
+// Start tidak boleh blocking; proses yang berjalan lama didaftarkan sebagai component di kernel.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper diimplementasikan module yang perlu membersihkan resource saat aplikasi berhenti
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
@@ -0,0 +1,103 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrDuplicateModule dikembalikan Resolve saat dua module memakai nama yang sama
+	ErrDuplicateModule = errors.New("duplicate module")
+
+	// ErrMissingDependency dikembalikan Resolve saat module membutuhkan module yang tidak ada atau di-disable
+	ErrMissingDependency = errors.New("missing module dependency")
+
+	// ErrCircularDependency dikembalikan Resolve saat dependency antar module membentuk cycle
+	ErrCircularDependency = errors.New("circular module dependency")
+)
+
+// Name mengembalikan nama module: hasil Name() jika ada, selain itu nama tipenya
+func Name(m Module) string {
+	if n, ok := m.(Named); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", m)
+}
+
+// Resolve membuang module yang di-disable oleh cfg, lalu mengurutkan sisanya sehingga
+// setiap module berada setelah module yang ada di DependsOn-nya. Module yang tidak saling
+// bergantung tetap dalam urutan aslinya. cfg boleh nil.
+func Resolve(modules []Module, cfg *Config) ([]Module, error) {
+	byName := make(map[string]Module, len(modules))
+	var names []string
+	var errs []error
+	for _, m := range modules {
+		name := Name(m)
+		if !cfg.IsEnabled(name) {
+			continue
+		}
+		if _, exists := byName[name]; exists {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrDuplicateModule, name))
+			continue
+		}
+		byName[name] = m
+		names = append(names, name)
+	}
+
+	for _, name := range names {
+		for _, dep := range dependsOn(byName[name]) {
+			if _, ok := byName[dep]; !ok {
+				errs = append(errs, fmt.Errorf("%w: %s requires %s", ErrMissingDependency, name, dep))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// DFS: dependency selalu ditambahkan sebelum module yang membutuhkannya
+	const (
+		visiting = iota + 1
+		done
+	)
+	state := make(map[string]int, len(names))
+	sorted := make([]Module, 0, len(names))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			cycle := append(slices.Clone(path[slices.Index(path, name):]), name)
+			return fmt.Errorf("%w: %s", ErrCircularDependency, strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		for _, dep := range dependsOn(byName[name]) {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		sorted = append(sorted, byName[name])
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
+
+func dependsOn(m Module) []string {
+	if d, ok := m.(Dependent); ok {
+		return d.DependsOn()
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package module
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testModule struct {
+	name string
+	deps []string
+}
+
+func (m *testModule) Register()           {}
+func (m *testModule) Name() string        { return m.name }
+func (m *testModule) DependsOn() []string { return m.deps }
+
+func names(modules []Module) string {
+	var out []string
+	for _, m := range modules {
+		out = append(out, Name(m))
+	}
+	return strings.Join(out, ",")
+}
+
+func TestResolveOrdersByDependency(t *testing.T) {
+	modules := []Module{
+		&testModule{name: "billing", deps: []string{"users", "payments"}},
+		&testModule{name: "users"},
+		&testModule{name: "reports"},
+		&testModule{name: "payments", deps: []string{"users"}},
+	}
+
+	sorted, err := Resolve(modules, nil)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got := names(sorted); got != "users,payments,billing,reports" {
+		t.Errorf("Unexpected order %s", got)
+	}
+}
+
+func TestResolveCycle(t *testing.T) {
+	_, err := Resolve([]Module{
+		&testModule{name: "a", deps: []string{"b"}},
+		&testModule{name: "b", deps: []string{"c"}},
+		&testModule{name: "c", deps: []string{"a"}},
+	}, nil)
+
+	if !errors.Is(err, ErrCircularDependency) || !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("Expected cycle a -> b -> c -> a, got %v", err)
+	}
+}
+
+func TestResolveWithConfig(t *testing.T) {
+	modules := []Module{
+		&testModule{name: "users"},
+		&testModule{name: "reports"},
+		&testModule{name: "billing", deps: []string{"users"}},
+	}
+
+	sorted, err := Resolve(modules, &Config{Disabled: []string{"reports"}})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got := names(sorted); got != "users,billing" {
+		t.Errorf("Unexpected modules %s", got)
+	}
+
+	_, err = Resolve(modules, &Config{Enabled: []string{"billing"}})
+	if !errors.Is(err, ErrMissingDependency) {
+		t.Errorf("Expected missing dependency when users is not enabled, got %v", err)
+	}
+}
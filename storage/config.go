@@ -10,8 +10,8 @@ type S3Config struct {
 	Region               string
 	Bucket               string
 	AccessKey            string
-	SecretKey            string
-	Session              string
+	SecretKey            support.Secret
+	Session              support.Secret
 	Url                  string // url for generate url, if fill this field, it will be used to generate url for file, example https://minio.example.com for usePathStyleEndpoint = true, and https://bucket.minio.example.com for usePathStyleEndpoint = false
 	UseStylePathEndpoint bool   // if true, format will be s3.amazonaws.com/bucket, if false, format will be bucket.s3.amazonaws.com
 }
@@ -21,8 +21,8 @@ func LoadS3Config() *S3Config {
 		Region:               support.GetEnv("STORAGE_S3_REGION", "us-east-1"),
 		Bucket:               support.GetEnv("STORAGE_S3_BUCKET", ""),
 		AccessKey:            support.GetEnv("STORAGE_S3_ACCESS_KEY", ""),
-		SecretKey:            support.GetEnv("STORAGE_S3_SECRET_KEY", ""),
-		Session:              support.GetEnv("STORAGE_S3_SESSION", ""),
+		SecretKey:            support.MustGetSecretEnv("STORAGE_S3_SECRET_KEY", ""),
+		Session:              support.MustGetSecretEnv("STORAGE_S3_SESSION", ""),
 		Url:                  support.GetEnv("STORAGE_S3_URL", ""),
 		UseStylePathEndpoint: support.GetBoolEnv("STORAGE_S3_USE_STYLE_PATH_ENDPOINT", false),
 	}
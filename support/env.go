@@ -1,22 +1,77 @@
 package support
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// LookupEnv membaca environment key. Jika key kosong dan key+"_FILE" berisi path,
+// value dibaca dari file tersebut (konvensi Docker secrets), tanpa newline di akhir.
+// ok bernilai false jika keduanya tidak di-set; err berisi error saat membaca file,
+// dan value kosong tidak boleh dianggap "tidak di-set" dalam kasus itu.
+func LookupEnv(key string) (value string, ok bool, err error) {
+	if value = os.Getenv(key); value != "" {
+		return value, true, nil
+	}
+
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return "", false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("%s_FILE: %w", key, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// lookupEnv dipakai GetEnv dan kawan-kawan. Error saat membaca KEY_FILE diperlakukan sebagai
+// "tidak di-set" sehingga default dipakai; gunakan LookupEnv atau MustGetSecretEnv untuk mendapatkannya.
+func lookupEnv(key string) (string, bool) {
+	value, ok, err := LookupEnv(key)
+	if err != nil || value == "" {
+		return "", false
+	}
+	return value, ok
+}
+
 func GetEnv(key, defaultValue string) string {
-	value := os.Getenv(key)
-	if value == "" {
+	value, ok := lookupEnv(key)
+	if !ok {
 		return defaultValue
 	}
 	return value
 }
 
+// GetSecretEnv sama seperti GetEnv, tetapi hasilnya Secret supaya tidak bocor ke log
+func GetSecretEnv(key string, defaultValue Secret) Secret {
+	value, ok := lookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	return Secret(value)
+}
+
+// MustGetSecretEnv sama seperti GetSecretEnv, tetapi panic jika KEY_FILE di-set dan file-nya
+// tidak bisa dibaca, supaya secret mount yang salah tidak diam-diam diganti value default
+func MustGetSecretEnv(key string, defaultValue Secret) Secret {
+	value, ok, err := LookupEnv(key)
+	if err != nil {
+		panic("support: " + err.Error())
+	}
+	if !ok || value == "" {
+		return defaultValue
+	}
+	return Secret(value)
+}
+
 func GetIntEnv(key string, defaultValue int) int {
-	value := os.Getenv(key)
-	if value == "" {
+	value, ok := lookupEnv(key)
+	if !ok {
 		return defaultValue
 	}
 	intVal, err := strconv.Atoi(value)
@@ -27,8 +82,8 @@ func GetIntEnv(key string, defaultValue int) int {
 }
 
 func GetBoolEnv(key string, defaultValue bool) bool {
-	value := os.Getenv(key)
-	if value == "" {
+	value, ok := lookupEnv(key)
+	if !ok {
 		return defaultValue
 	}
 	boolVal, err := strconv.ParseBool(value)
@@ -39,8 +94,8 @@ func GetBoolEnv(key string, defaultValue bool) bool {
 }
 
 func GetDurationEnv(key string, defaultValue time.Duration) time.Duration {
-	value := os.Getenv(key)
-	if value == "" {
+	value, ok := lookupEnv(key)
+	if !ok {
 		return defaultValue
 	}
 	durationVal, err := time.ParseDuration(value)
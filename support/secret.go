@@ -0,0 +1,73 @@
+package support
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+const redacted = "[REDACTED]"
+
+// Secret adalah string sensitif (password, API key, token) yang tidak pernah tampil
+// di fmt, log, JSON, YAML maupun slog/zap. Pakai Reveal untuk mengambil value aslinya.
+// Secret kosong ditampilkan sebagai string kosong, supaya terlihat kalau belum di-set.
+type Secret string
+
+var (
+	_ fmt.Stringer   = Secret("")
+	_ fmt.GoStringer = Secret("")
+	_ fmt.Formatter  = Secret("")
+	_ json.Marshaler = Secret("")
+	_ slog.LogValuer = Secret("")
+)
+
+// Reveal mengembalikan value asli. Jangan log hasilnya.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// IsEmpty mengecek apakah secret belum di-set
+func (s Secret) IsEmpty() bool {
+	return s == ""
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return fmt.Sprintf("support.Secret(%q)", s.String())
+}
+
+// Format membuat semua verb fmt (%s, %v, %q, %x, ...) menampilkan value yang sudah disamarkan
+func (s Secret) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			fmt.Fprint(f, s.GoString())
+			return
+		}
+		fmt.Fprint(f, s.String())
+	case 'q':
+		fmt.Fprintf(f, "%q", s.String())
+	default:
+		fmt.Fprint(f, s.String())
+	}
+}
+
+// MarshalText juga dipakai encoder YAML dan XML
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// MarshalJSON juga dipakai zap saat mencatat struct yang berisi Secret
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
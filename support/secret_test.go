@@ -0,0 +1,101 @@
+package support
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type smtpConfig struct {
+	Host     string
+	Password Secret
+}
+
+func TestSecretRedaction(t *testing.T) {
+	cfg := smtpConfig{Host: "smtp.test", Password: "hunter2"}
+
+	outputs := []string{
+		cfg.Password.String(),
+		fmt.Sprint(cfg.Password),
+		fmt.Sprintf("%s %q %x %+v %#v", cfg.Password, cfg.Password, cfg.Password, cfg, cfg),
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs = append(outputs, string(data))
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("config", "password", cfg.Password, "cfg", cfg)
+	outputs = append(outputs, buf.String())
+
+	for _, out := range outputs {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("Secret leaked in %q", out)
+		}
+		if !strings.Contains(out, redacted) {
+			t.Errorf("Expected %s in %q", redacted, out)
+		}
+	}
+
+	if cfg.Password.Reveal() != "hunter2" {
+		t.Error("Expected Reveal to return the original value")
+	}
+	if Secret("").String() != "" {
+		t.Error("Expected empty secret to stay empty")
+	}
+}
+
+func TestEnvFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "smtp_password")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TEST_SMTP_PASSWORD_FILE", path)
+	if got := GetSecretEnv("TEST_SMTP_PASSWORD", ""); got.Reveal() != "from-file" {
+		t.Errorf("Expected value from file, got %q", got.Reveal())
+	}
+
+	t.Setenv("TEST_SMTP_PASSWORD", "from-env")
+	if got := GetEnv("TEST_SMTP_PASSWORD", ""); got != "from-env" {
+		t.Errorf("Expected env to win over file, got %q", got)
+	}
+
+	t.Setenv("TEST_MISSING_FILE", filepath.Join(t.TempDir(), "missing"))
+	value, ok, err := LookupEnv("TEST_MISSING")
+	if err == nil || ok || value != "" {
+		t.Errorf("Expected error without value for unreadable file, got %q, %v, %v", value, ok, err)
+	}
+}
+
+func TestEnvFromMissingFile(t *testing.T) {
+	t.Setenv("TEST_MISSING_FILE", filepath.Join(t.TempDir(), "missing"))
+
+	if got := GetEnv("TEST_MISSING", "fallback"); got != "fallback" {
+		t.Errorf("Expected GetEnv to fall back to the default, got %q", got)
+	}
+	if got := GetIntEnv("TEST_MISSING", 1); got != 1 {
+		t.Errorf("Expected GetIntEnv to fall back to the default, got %d", got)
+	}
+	if got := GetSecretEnv("TEST_MISSING", "fallback"); got.Reveal() != "fallback" {
+		t.Errorf("Expected GetSecretEnv to fall back to the default, got %q", got.Reveal())
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Expected MustGetSecretEnv to panic instead of falling back to the default")
+		}
+		if msg := fmt.Sprint(r); !strings.Contains(msg, "TEST_MISSING_FILE") {
+			t.Errorf("Expected panic to name the variable, got %q", msg)
+		}
+	}()
+	MustGetSecretEnv("TEST_MISSING", "fallback")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"reflect"
 	"strings"
 	"time"
 
@@ -37,9 +38,9 @@ func validateRequired(message string) Rule {
 			return &Error{Field: field, Message: msg}
 		}
 
-		// Jika string, cek kosong / spasi
-		if s, ok := value.(string); ok {
-			if strings.TrimSpace(s) == "" {
+		// Jika string (termasuk tipe turunan string seperti support.Secret), cek kosong / spasi
+		if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+			if strings.TrimSpace(v.String()) == "" {
 				if msg == "" {
 					msg = ErrorMessageRequired
 				}
@@ -53,6 +53,10 @@ type Config struct {
 	// Signature: func(templateType, name string) string
 	// templateType: "layout", "component", "view"
 	PathResolver func(templateType, name string) string
+
+	// URLFunc dipakai function template "url" untuk membuat URL dari route bernama,
+	// misalnya httprouter.Router.URL. Signature: func(name string, params ...any) (string, error)
+	URLFunc func(name string, params ...any) (string, error)
 }
 
 type view struct {
@@ -171,6 +175,14 @@ func (v *view) registerDefaultFuncs() {
 		return val
 	}
 
+	// {{ url "users.show" "id" .ID }}
+	v.funcMap["url"] = func(name string, params ...any) (string, error) {
+		if v.config.URLFunc == nil {
+			return "", fmt.Errorf("url %q: Config.URLFunc is not set", name)
+		}
+		return v.config.URLFunc(name, params...)
+	}
+
 	// Global data accessor
 	v.funcMap["global"] = func(key ...string) any {
 		v.globalMu.RLock()